@@ -0,0 +1,206 @@
+// Package blackout evaluates MLB.TV blackouts. A game is blacked out on
+// MLB.TV where either team holds the local TV rights and everywhere in the
+// US while a national network has it exclusively. Both the territories and
+// the national exclusivity windows are embedded approximations
+package blackout
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/unrealities/warning-track-backend/transformers"
+)
+
+// The Availability blackout reasons
+const (
+	ReasonLocal    = "local"
+	ReasonNational = "national"
+)
+
+// torontoBlueJays is the team blacked out everywhere in Canada
+const torontoBlueJays int64 = 141
+
+//go:embed territories.csv
+var territoriesCSV string
+
+var (
+	defaultTerritories *Territories
+	defaultOnce        sync.Once
+)
+
+// Territories maps US ZIP code prefixes to the teams blacked out on MLB.TV there
+type Territories struct {
+	zip3 map[string][]int64
+}
+
+// DefaultTerritories returns the Territories embedded in the package
+func DefaultTerritories() *Territories {
+	defaultOnce.Do(func() {
+		t, err := ParseTerritories(strings.NewReader(territoriesCSV))
+		if err != nil {
+			panic(err)
+		}
+		defaultTerritories = t
+	})
+	return defaultTerritories
+}
+
+// ParseTerritories reads a territory dataset. Each line is a ZIP3 or an
+// inclusive range of ZIP3s, a comma and the team IDs blacked out there
+// separated by spaces. Blank lines and lines starting with # are skipped
+func ParseTerritories(r io.Reader) (*Territories, error) {
+	t := &Territories{zip3: map[string][]int64{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		prefixes, teams, ok := strings.Cut(text, ",")
+		if !ok {
+			return nil, fmt.Errorf("blackout#ParseTerritories: line %d: missing teams", line)
+		}
+		first, last, ok := strings.Cut(prefixes, "-")
+		if !ok {
+			last = first
+		}
+		from, err := parseZIP3(first)
+		if err != nil {
+			return nil, fmt.Errorf("blackout#ParseTerritories: line %d: %w", line, err)
+		}
+		to, err := parseZIP3(last)
+		if err != nil {
+			return nil, fmt.Errorf("blackout#ParseTerritories: line %d: %w", line, err)
+		}
+		var ids []int64
+		for _, f := range strings.Fields(teams) {
+			id, err := strconv.ParseInt(f, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("blackout#ParseTerritories: line %d: %w", line, err)
+			}
+			ids = append(ids, id)
+		}
+		for z := from; z <= to; z++ {
+			key := fmt.Sprintf("%03d", z)
+			t.zip3[key] = append(t.zip3[key], ids...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("blackout#ParseTerritories: %w", err)
+	}
+	return t, nil
+}
+
+// parseZIP3 parses a three digit ZIP code prefix
+func parseZIP3(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 3 {
+		return 0, fmt.Errorf("invalid ZIP3 %q", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// Teams returns the teams blacked out at a postal code. US ZIP codes are
+// looked up by their first three digits and Canadian postal codes black out
+// the Blue Jays. Anywhere else has no local blackouts
+func (t *Territories) Teams(postalCode string) []int64 {
+	code := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postalCode), " ", ""))
+	switch {
+	case isZIP(code):
+		return t.zip3[code[:3]]
+	case isCanadianPostalCode(code):
+		return []int64{torontoBlueJays}
+	}
+	return nil
+}
+
+// isZIP reports if code is a US ZIP or ZIP+4 code
+func isZIP(code string) bool {
+	code = strings.ReplaceAll(code, "-", "")
+	if len(code) != 5 && len(code) != 9 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanadianPostalCode reports if code is a Canadian postal code, e.g. M5V3L9
+func isCanadianPostalCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for i, c := range code {
+		letter := c >= 'A' && c <= 'Z'
+		if letter != (i%2 == 0) {
+			return false
+		}
+		if !letter && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate reports whether g can be watched on MLB.TV at postalCode using the embedded territories
+func Evaluate(postalCode string, g transformers.Game) transformers.Availability {
+	return DefaultTerritories().Evaluate(postalCode, g)
+}
+
+// Evaluate reports whether g can be watched on MLB.TV at postalCode and the
+// TV networks it airs on there, using the embedded national exclusivity windows
+func (t *Territories) Evaluate(postalCode string, g transformers.Game) transformers.Availability {
+	return t.EvaluateWith(DefaultNationalWindows(), postalCode, g)
+}
+
+// EvaluateWith reports whether g can be watched on MLB.TV at postalCode and
+// the TV networks it airs on there. A team's local broadcast only airs in its
+// territory and national broadcasts air everywhere in the US. National
+// broadcasts only black out MLB.TV in their network's exclusivity window
+func (t *Territories) EvaluateWith(national *NationalWindows, postalCode string, g transformers.Game) transformers.Availability {
+	a := transformers.Availability{MLBTV: true, PostalCode: postalCode}
+	us := isZIP(strings.TrimSpace(postalCode))
+
+	local := map[string]bool{}
+	for _, id := range t.Teams(postalCode) {
+		if id == int64(g.Teams.AwayID) {
+			local[transformers.FeedAway] = true
+		}
+		if id == int64(g.Teams.HomeID) {
+			local[transformers.FeedHome] = true
+		}
+	}
+	if len(local) > 0 {
+		a.Blackout = ReasonLocal
+		a.MLBTV = false
+	}
+
+	seen := map[string]bool{}
+	for _, b := range g.Broadcasts {
+		if b.Medium != transformers.MediumTV || b.Network == "" {
+			continue
+		}
+		switch {
+		case b.Feed == transformers.FeedNational && us:
+			if national.Exclusive(b.Network, g.GameTime) {
+				a.Blackout = ReasonNational
+				a.MLBTV = false
+			}
+		case !local[b.Feed]:
+			continue
+		}
+		if !seen[b.Network] {
+			seen[b.Network] = true
+			a.Networks = append(a.Networks, b.Network)
+		}
+	}
+	return a
+}
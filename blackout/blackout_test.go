@@ -0,0 +1,64 @@
+package blackout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unrealities/warning-track-backend/transformers"
+)
+
+func TestEvaluate(t *testing.T) {
+	eastern, err := time.LoadLocation(NationalZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Yankees at Red Sox
+	game := func(gameTime time.Time, broadcasts ...transformers.Broadcast) transformers.Game {
+		return transformers.Game{
+			Broadcasts: broadcasts,
+			GameTime:   gameTime.UTC(),
+			Teams:      transformers.Teams{AwayID: 147, HomeID: 111},
+		}
+	}
+	national := func(network string) transformers.Broadcast {
+		return transformers.Broadcast{Feed: transformers.FeedNational, Medium: transformers.MediumTV, Network: network}
+	}
+	home := transformers.Broadcast{Feed: transformers.FeedHome, Medium: transformers.MediumTV, Network: "NESN"}
+
+	saturdayNight := time.Date(2026, time.June, 6, 19, 15, 0, 0, eastern)
+	saturdayAfternoon := time.Date(2026, time.June, 6, 16, 10, 0, 0, eastern)
+	tuesdayNight := time.Date(2026, time.June, 9, 19, 7, 0, 0, eastern)
+	friday := time.Date(2026, time.June, 12, 19, 10, 0, 0, eastern)
+
+	tests := []struct {
+		name       string
+		postalCode string
+		game       transformers.Game
+		blackout   string
+		networks   []string
+	}{
+		{"out of market", "80202", game(tuesdayNight, home), "", nil},
+		{"in market", "02215", game(tuesdayNight, home), ReasonLocal, []string{"NESN"}},
+		{"FOX Saturday night", "80202", game(saturdayNight, national("FOX")), ReasonNational, []string{"FOX"}},
+		{"FOX Saturday afternoon", "80202", game(saturdayAfternoon, national("FOX")), "", []string{"FOX"}},
+		{"FS1 is never exclusive", "80202", game(saturdayNight, national("FS1")), "", []string{"FS1"}},
+		{"MLB Network showcase", "80202", game(tuesdayNight, national("MLBN")), "", []string{"MLBN"}},
+		{"TBS Tuesday", "80202", game(tuesdayNight, national("TBS")), ReasonNational, []string{"TBS"}},
+		{"Apple TV+ Friday", "80202", game(friday, national("Apple TV+")), ReasonNational, []string{"Apple TV+"}},
+		{"national outside the US", "M5V3L9", game(saturdayNight, national("FOX")), "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Evaluate(tt.postalCode, tt.game)
+			if a.Blackout != tt.blackout {
+				t.Errorf("Blackout = %q, want %q", a.Blackout, tt.blackout)
+			}
+			if a.MLBTV != (tt.blackout == "") {
+				t.Errorf("MLBTV = %v with blackout %q", a.MLBTV, a.Blackout)
+			}
+			if len(a.Networks) != len(tt.networks) || len(a.Networks) > 0 && a.Networks[0] != tt.networks[0] {
+				t.Errorf("Networks = %v, want %v", a.Networks, tt.networks)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package blackout
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NationalZone is the time zone national exclusivity windows are kept in
+const NationalZone = "America/New_York"
+
+//go:embed national.csv
+var nationalCSV string
+
+var (
+	defaultNational     *NationalWindows
+	defaultNationalOnce sync.Once
+)
+
+// NationalWindows are the times national networks hold a game's US rights exclusively
+type NationalWindows struct {
+	loc     *time.Location
+	windows map[string][]nationalWindow
+}
+
+// nationalWindow is a weekly exclusivity window of a network, as the time since midnight
+type nationalWindow struct {
+	end     time.Duration
+	start   time.Duration
+	weekday time.Weekday
+}
+
+// DefaultNationalWindows returns the NationalWindows embedded in the package
+func DefaultNationalWindows() *NationalWindows {
+	defaultNationalOnce.Do(func() {
+		w, err := ParseNationalWindows(strings.NewReader(nationalCSV))
+		if err != nil {
+			panic(err)
+		}
+		defaultNational = w
+	})
+	return defaultNational
+}
+
+// ParseNationalWindows reads an exclusivity window dataset. Each line is a
+// network's call letters, a weekday and the window's start and end in
+// NationalZone as HH:MM, separated by commas. Blank lines and lines starting
+// with # are skipped
+func ParseNationalWindows(r io.Reader) (*NationalWindows, error) {
+	loc, err := time.LoadLocation(NationalZone)
+	if err != nil {
+		return nil, fmt.Errorf("blackout#ParseNationalWindows: %w", err)
+	}
+	n := &NationalWindows{loc: loc, windows: map[string][]nationalWindow{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("blackout#ParseNationalWindows: line %d: want 4 fields, got %d", line, len(fields))
+		}
+		weekday, err := parseWeekday(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("blackout#ParseNationalWindows: line %d: %w", line, err)
+		}
+		start, err := parseClock(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("blackout#ParseNationalWindows: line %d: %w", line, err)
+		}
+		end, err := parseClock(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("blackout#ParseNationalWindows: line %d: %w", line, err)
+		}
+		network := normalizeNetwork(fields[0])
+		n.windows[network] = append(n.windows[network], nationalWindow{end: end, start: start, weekday: weekday})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("blackout#ParseNationalWindows: %w", err)
+	}
+	return n, nil
+}
+
+// parseWeekday parses an English weekday name, e.g. Saturday
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.TrimSpace(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// parseClock parses a HH:MM time of day as the time since midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// normalizeNetwork returns call letters as they are matched, e.g. "apple tv+" for "Apple TV+"
+func normalizeNetwork(network string) string {
+	return strings.ToLower(strings.TrimSpace(network))
+}
+
+// Exclusive reports if network holds a game starting at gameTime exclusively
+func (n *NationalWindows) Exclusive(network string, gameTime time.Time) bool {
+	if gameTime.IsZero() {
+		return false
+	}
+	local := gameTime.In(n.loc)
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	for _, w := range n.windows[normalizeNetwork(network)] {
+		if w.weekday == local.Weekday() && clock >= w.start && clock <= w.end {
+			return true
+		}
+	}
+	return false
+}
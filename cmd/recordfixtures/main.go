@@ -0,0 +1,233 @@
+// Command recordfixtures records the StatsAPI fixture corpus used to run the
+// mlbstats client offline. It scans a season's schedule for the first date of
+// each scenario (off-day, doubleheader, postponement, extra innings and spring
+// training) and records every request GetGameDataByDay makes for that date:
+// the schedule and single day range with GameConditionsHydrations, standings,
+// the season's teams, venues and active rosters, and the live feed, win probability and
+// content of each game it enriches. A scenarios.json manifest is written next to the fixtures.
+//
+//	go run ./cmd/recordfixtures -season 2023 -dir mlbstats/testdata/statsapi
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// scanDays is how many days of schedule are scanned per StatsAPI request
+const scanDays = 14
+
+// peoplePerRequest is how many people the function requests at once
+const peoplePerRequest = 100
+
+// Scenario is a recorded schedule date that covers one edge case
+type Scenario struct {
+	Date   string `json:"date"`
+	GamePk int64  `json:"gamePk,omitempty"`
+	Name   string `json:"name"`
+}
+
+// scenarios matches a game to each scenario. Off-days are found separately
+var scenarios = []struct {
+	name  string
+	match func(g mlbstats.Game) bool
+}{
+	{"spring-training", func(g mlbstats.Game) bool {
+		return mlbstats.GameType(g.GameType) == mlbstats.GameTypeSpringTraining
+	}},
+	{"doubleheader", func(g mlbstats.Game) bool {
+		return g.DoubleHeader != "" && g.DoubleHeader != "N" && g.Status.IsFinal()
+	}},
+	{"postponement", func(g mlbstats.Game) bool {
+		return g.Status.IsPostponed()
+	}},
+	{"extra-innings", func(g mlbstats.Game) bool {
+		return g.Status.IsFinal() && g.ScheduledInnings > 0 && g.Linescore.CurrentInning > g.ScheduledInnings
+	}},
+}
+
+func main() {
+	season := flag.Int("season", time.Now().Year()-1, "season to find scenarios in")
+	dir := flag.String("dir", filepath.Join("mlbstats", "testdata", "statsapi"), "fixture directory")
+	sport := flag.String("sport", "mlb", "sport slug or StatsAPI sport ID")
+	flag.Parse()
+
+	sportID, err := mlbstats.ParseSportID(*sport)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	found, err := findScenarios(ctx, mlbstats.NewClient(), sportID, *season)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	recorder := mlbstats.NewClient()
+	recorder.HTTPClient.Transport = mlbstats.NewRecorder(*dir, mlbstats.ModeRecord)
+	err = recordReference(ctx, recorder, sportID, *season)
+	if err != nil {
+		log.Fatalf("recording reference data: %v", err)
+	}
+	for _, s := range found {
+		date, _ := time.Parse("2006-01-02", s.Date)
+		err = recordDay(ctx, recorder, sportID, date)
+		if err != nil {
+			log.Fatalf("recording %s: %v", s.Name, err)
+		}
+		log.Printf("recorded %s: %s %d", s.Name, s.Date, s.GamePk)
+	}
+
+	manifest, err := json.MarshalIndent(found, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(*dir, "scenarios.json"), append(manifest, '\n'), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// recordReference records the season's teams, venues, active rosters and the
+// people on them, which the function joins into every game
+func recordReference(ctx context.Context, c *mlbstats.Client, sportID mlbstats.SportID, season int) error {
+	teams, err := c.GetTeams(ctx, sportID, season)
+	if err != nil {
+		return err
+	}
+	_, err = c.GetVenues(ctx, sportID, season)
+	if err != nil {
+		return err
+	}
+
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, t := range teams {
+		roster, err := c.GetRoster(ctx, t.ID, season)
+		if err != nil {
+			return err
+		}
+		for _, r := range roster {
+			if !seen[r.Person.ID] {
+				seen[r.Person.ID] = true
+				ids = append(ids, r.Person.ID)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for start := 0; start < len(ids); start += peoplePerRequest {
+		_, err = c.GetPeople(ctx, ids[start:min(start+peoplePerRequest, len(ids))]...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordDay makes the requests the function makes for date: the schedule, the
+// same date as a range, MLB standings, and the live feed, win probability and
+// content of every live or final game
+func recordDay(ctx context.Context, c *mlbstats.Client, sportID mlbstats.SportID, date time.Time) error {
+	schedule, err := c.GetSchedule(ctx, sportID, date, mlbstats.GameConditionsHydrations...)
+	if err != nil {
+		return err
+	}
+	_, err = c.GetScheduleRange(ctx, sportID, date, date, mlbstats.GameConditionsHydrations...)
+	if err != nil {
+		return err
+	}
+	if sportID == mlbstats.SportMLB {
+		_, err = c.GetStandings(ctx, date, mlbstats.LeagueAmerican, mlbstats.LeagueNational)
+		if err != nil {
+			return err
+		}
+	}
+	for _, d := range schedule.Dates {
+		for _, g := range d.Games {
+			if !g.Status.IsLive() && !g.Status.IsFinal() {
+				continue
+			}
+			_, err = c.GetLiveFeed(ctx, g.GamePk)
+			if err != nil {
+				return err
+			}
+			_, err = c.GetWinProbability(ctx, g.GamePk)
+			if err != nil {
+				return err
+			}
+			_, err = c.GetGameContent(ctx, g.GamePk)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findScenarios scans the season's schedule for the first date of each scenario
+func findScenarios(ctx context.Context, c *mlbstats.Client, sportID mlbstats.SportID, season int) ([]Scenario, error) {
+	start := time.Date(season, time.February, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(season, time.November, 15, 0, 0, 0, 0, time.UTC)
+	q := mlbstats.NewQuery("/api/v1/schedule").
+		Sports(sportID).
+		Hydrate(mlbstats.Hydrate("linescore"))
+
+	found := map[string]Scenario{}
+	var regularSeason []string
+	for day := start; day.Before(end); day = day.AddDate(0, 0, scanDays) {
+		last := day.AddDate(0, 0, scanDays-1)
+		schedule, err := c.Schedule(ctx, q.Date("startDate", day).Date("endDate", last))
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range schedule.Dates {
+			for _, g := range d.Games {
+				if mlbstats.GameType(g.GameType) == mlbstats.GameTypeRegularSeason {
+					regularSeason = append(regularSeason, d.Date)
+				}
+				for _, s := range scenarios {
+					if _, ok := found[s.name]; !ok && s.match(g) {
+						found[s.name] = Scenario{Date: d.Date, GamePk: g.GamePk, Name: s.name}
+					}
+				}
+			}
+		}
+	}
+
+	// An off-day is a date without games between the first and last regular season games
+	sort.Strings(regularSeason)
+	if len(regularSeason) > 0 {
+		played := map[string]bool{}
+		for _, d := range regularSeason {
+			played[d] = true
+		}
+		first, _ := time.Parse("2006-01-02", regularSeason[0])
+		last, _ := time.Parse("2006-01-02", regularSeason[len(regularSeason)-1])
+		for day := first; day.Before(last); day = day.AddDate(0, 0, 1) {
+			if date := day.Format("2006-01-02"); !played[date] {
+				found["off-day"] = Scenario{Date: date, Name: "off-day"}
+				break
+			}
+		}
+	}
+
+	list := make([]Scenario, 0, len(found))
+	for _, s := range found {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	for _, s := range scenarios {
+		if _, ok := found[s.name]; !ok {
+			log.Printf("no %s found in the %d season", s.name, season)
+		}
+	}
+	return list, nil
+}
@@ -1,9 +1,18 @@
 package function
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unrealities/warning-track-backend/blackout"
 	"github.com/unrealities/warning-track-backend/mlbstats"
 	"github.com/unrealities/warning-track-backend/transformers"
 
@@ -14,6 +23,12 @@ import (
 // Runs on Google Cloud Scheduler daily
 // ex. POST request:
 // https://us-central1-warning-track-backend.cloudfunctions.net/GetGameDataByDay -d {"data": {"date":"03-01-2020"}}
+// An endDate returns every day from date through endDate, keyed by date:
+// https://us-central1-warning-track-backend.cloudfunctions.net/GetGameDataByDay -d {"data": {"date":"03-01-2020", "endDate":"03-07-2020"}}
+// A sport (mlb, aaa, aa, high-a, single-a or wbc) selects the level, stored in its own collection:
+// https://us-central1-warning-track-backend.cloudfunctions.net/GetGameDataByDay -d {"data": {"date":"03-01-2020", "sport":"aaa"}}
+// A postalCode adds whether each game can be watched on MLB.TV there and on which TV networks:
+// https://us-central1-warning-track-backend.cloudfunctions.net/GetGameDataByDay -d {"data": {"date":"03-01-2020", "postalCode":"10001"}}
 func GetGameDataByDay(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers for the preflight request
 	if r.Method == http.MethodOptions {
@@ -25,7 +40,8 @@ func GetGameDataByDay(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
 	s, err := InitService(ctx) // Execution Time: ~300ms
 	if err != nil {
 		s.HandleFatalError("error initializing service", err)
@@ -35,34 +51,361 @@ func GetGameDataByDay(w http.ResponseWriter, r *http.Request) {
 	defer s.Logger.Close()
 	defer s.TraceSpan.End()
 
-	date, err := ParseDate(r.Body, s.DateFmt)
+	req, err := ParseRequest(r.Body, s.DateFmt)
 	if err != nil {
-		s.HandleFatalError("error parsing date requested", err)
+		s.HandleWarning(w, http.StatusBadRequest, "error parsing date requested", err)
+		return
+	}
+	s.Date = req.Date
+	s.PostalCode = req.PostalCode
+	s.SchemaVersion = req.SchemaVersion
+	s.DBCollection = SportCollection(s.DBCollection, req.Sport)
+	s.Sport = req.Sport
+
+	// Strict decoding records any StatsAPI schema drift found while extracting
+	if s.StrictDecode {
+		s.Drift = &mlbstats.DriftReport{}
+		ctx = mlbstats.WithDriftReport(ctx, s.Drift)
+		defer s.ReportDrift(s.Drift)
+	}
+
+	if req.EndDate.After(req.Date) {
+		s.getGameDataByDateRange(ctx, w, req.Date, req.EndDate)
+		return
 	}
-	s.Date = date
 
 	// Extract
-	daySchedule, err := mlbstats.GetSchedule(s.Date) // Execution Time: ~1000ms
+	daySchedule, err := s.StatsAPI.GetSchedule(ctx, s.Sport, s.Date, mlbstats.GameConditionsHydrations...) // Execution Time: ~1000ms
 	if err != nil {
-		s.HandleFatalError("error getting the daily StatsAPI schedule", err)
+		s.handleStatsAPIError(w, "error getting the daily StatsAPI schedule", err)
+		return
 	}
 	s.DebugMsg("successfully fetched schedule")
 
 	// Transform
 	games, err := transformers.OptimusPrime(s.Date, daySchedule)
 	if err != nil {
-		s.HandleFatalError("error transforming StatsAPI schedule to simpler games struct", err)
+		s.handleStatsAPIError(w, "error transforming StatsAPI schedule to simpler games struct", err)
+		return
+	}
+	doc := s.FirestoreClient.Collection(s.DBCollection).Doc(s.Date.Format(s.DateFmt))
+	prev, err := previousGames(ctx, doc)
+	if err != nil {
+		s.WarningMsg("error reading the previous game data snapshot", err)
 	}
+	games = s.processDay(ctx, games, prev, s.loadReferenceData(ctx), s.playoffStandings(ctx, s.Date))
 	s.DebugMsg("successfully transformed data")
 
 	// Load
-	_, err = s.FirestoreClient.Collection(s.DBCollection).Doc(date.Format(s.DateFmt)).Set(ctx, games) // Execution Time: ~ 3500ms
+	day := s.Date.Format("2006-01-02")
+	filed, err := s.fileDays(ctx, map[string]transformers.AllSpark{day: games}, map[string]transformers.AllSpark{day: prev}) // Execution Time: ~ 3500ms
 	if err != nil {
-		s.HandleFatalError("error persisting data to Firebase", err)
+		s.HandleError(w, http.StatusInternalServerError, "error persisting data to Firebase", err)
+		return
 	}
+	games = filed[day]
 
 	// Send Response
+	games = transformers.Downgrade(s.withAvailability(games), s.SchemaVersion)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(games)
+}
+
+// getGameDataByDateRange responds with and persists the game data of every day
+// from start through end using a single StatsAPI schedule request. Each day
+// goes through the same pipeline as a single day, so today's live games are
+// still enriched and compared against their previous snapshot
+func (s Service) getGameDataByDateRange(ctx context.Context, w http.ResponseWriter, start, end time.Time) {
+	// Extract
+	days, err := s.StatsAPI.GetScheduleRange(ctx, s.Sport, start, end, mlbstats.GameConditionsHydrations...)
+	if err != nil {
+		s.handleStatsAPIError(w, "error getting the StatsAPI schedule range", err)
+		return
+	}
+	s.DebugMsg("successfully fetched schedule range")
+
+	// Transform
+	sparks := transformers.OptimusPrimeRange(days)
+	for day := range sparks {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			delete(sparks, day)
+		}
+	}
+	prevs, err := s.previousDays(ctx, sparks)
+	if err != nil {
+		s.WarningMsg("error reading the previous game data snapshots", err)
+	}
+	ref := s.loadReferenceData(ctx)
+	// Standings are only known up to today, so later days are tagged with today's
+	standingsDate := end
+	if today, err := transformers.BaseballDay(time.Now()); err == nil && today.Before(end) {
+		standingsDate = today
+	}
+	standings := s.playoffStandings(ctx, standingsDate)
+
+	for day, spark := range sparks {
+		sparks[day] = s.processDay(ctx, spark, prevs[day], ref, standings)
+	}
+	s.DebugMsg("successfully transformed data")
+
+	// Load
+	filed, err := s.fileDays(ctx, sparks, prevs)
+	if err != nil {
+		s.HandleError(w, http.StatusInternalServerError, "error persisting data to Firebase", err)
+		return
+	}
+
+	// Send Response, keyed by the requested date format
+	games := make(map[string]transformers.AllSpark, len(sparks))
+	for day := range sparks {
+		date, _ := time.Parse("2006-01-02", day)
+		games[date.Format(s.DateFmt)] = transformers.Downgrade(s.withAvailability(filed[day]), s.SchemaVersion)
+	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(games)
 }
+
+// processDay runs a day's games through reference data, live enrichment and
+// playoff tagging, then compares them against the day's previous snapshot.
+// standings is nil when playoff implications are not tagged
+func (s Service) processDay(ctx context.Context, games, prev transformers.AllSpark, ref transformers.Reference, standings *transformers.Standings) transformers.AllSpark {
+	games = transformers.JoinReference(games, ref)
+	s.enrichLiveGames(ctx, &games, prev)
+	if standings != nil {
+		for i := range games.Games {
+			games.Games[i].PlayoffImplications = transformers.PlayoffImplications(games.Games[i], *standings)
+		}
+	}
+
+	for _, err := range transformers.StatusTransitionErrors(prev, games) {
+		s.WarningMsg("impossible game status transition", err)
+	}
+	for _, change := range transformers.StarterChanges(prev, games) {
+		s.InfoMsg(change.String())
+	}
+	return transformers.DelayStarts(prev, games, time.Now())
+}
+
+// withAvailability adds each game's MLB.TV availability at the requested
+// postal code. It is only added to the response, never persisted, as it
+// depends on who is asking
+func (s Service) withAvailability(games transformers.AllSpark) transformers.AllSpark {
+	if s.PostalCode == "" {
+		return games
+	}
+	out := transformers.AllSpark{Games: make([]transformers.Game, len(games.Games)), SchemaVersion: games.SchemaVersion}
+	for i, g := range games.Games {
+		a := blackout.Evaluate(s.PostalCode, g)
+		g.Availability = &a
+		out.Games[i] = g
+	}
+	return out
+}
+
+// maxEnrichedGames bounds the live games enriched at once
+const maxEnrichedGames = 4
+
+// enrichLiveGames brings every live game's status up to date from its tracked
+// live feed, then adds its pitchers and timeline from the feed's boxscore and
+// plays, its highlights and its win probability. Games that were already over in prev keep
+// what they had, so late users still get what they missed, and games that just
+// ended are enriched one last time. Any of them missing only logs a warning, the game is still returned.
+// Enrichment gets enrichTimeout so it cannot eat into the time left to persist the games
+func (s Service) enrichLiveGames(ctx context.Context, games *transformers.AllSpark, prev transformers.AllSpark) {
+	*games = transformers.CarryOverFinal(prev, *games)
+	ctx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	defer cancel()
+
+	sem := make(chan struct{}, maxEnrichedGames)
+	var wg sync.WaitGroup
+	for i := range games.Games {
+		g := &games.Games[i]
+		live := g.Status.InProgress
+		if !live {
+			feedTrackers.Forget(g.MLBId)
+		}
+		if !live && !(g.Status.Over() && !g.Enriched()) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var feed mlbstats.LiveFeed
+			var err error
+			if live {
+				feed, err = feedTrackers.Tracker(g.MLBId).Update(ctx)
+			} else {
+				feed, err = s.StatsAPI.GetLiveFeed(ctx, g.MLBId)
+			}
+			if err != nil {
+				s.WarningMsg(fmt.Sprintf("error getting the live feed of game %d", g.MLBId), err)
+			} else {
+				transformers.UpdateFromLiveFeed(g, feed)
+				var currentID int64
+				if g.Pitchers.Current != nil {
+					currentID = g.Pitchers.Current.PlayerID
+				}
+				g.Pitchers = transformers.PitchersFromBoxscore(feed.LiveData.Boxscore, currentID)
+				g.Timeline = transformers.TimelineFromPlays(feed.LiveData.Plays, g.Status.ScheduledInnings)
+			}
+
+			content, err := s.StatsAPI.GetGameContent(ctx, g.MLBId)
+			if err != nil {
+				s.WarningMsg(fmt.Sprintf("error getting the highlights of game %d", g.MLBId), err)
+			} else {
+				g.Highlights = transformers.HighlightsFromContent(content)
+			}
+
+			wp, err := s.StatsAPI.GetWinProbability(ctx, g.MLBId)
+			if err != nil {
+				s.WarningMsg(fmt.Sprintf("error getting the win probability of game %d", g.MLBId), err)
+				return
+			}
+			g.WinProbability = transformers.WinProbabilityFromPlays(wp)
+			if !live {
+				return
+			}
+			err = transformers.CheckLeverageIndex(*g, wp)
+			if err != nil {
+				s.WarningMsg("leverage index disagrees with StatsAPI", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// playoffStandings returns the MLB standings as of date and stores them as
+// that day's standings snapshot next to the game data. Standings are optional,
+// so any failure only logs a warning and returns nil, as do other sports
+func (s Service) playoffStandings(ctx context.Context, date time.Time) *transformers.Standings {
+	if s.Sport != mlbstats.SportMLB {
+		return nil
+	}
+	st, err := s.StatsAPI.GetStandings(ctx, date, mlbstats.LeagueAmerican, mlbstats.LeagueNational)
+	if err != nil {
+		s.WarningMsg("error getting the StatsAPI standings", err)
+		return nil
+	}
+	standings := transformers.TransformStandings(st)
+
+	_, err = s.FirestoreClient.Collection(s.DBCollection+standingsCollectionSuffix).Doc(date.Format(s.DateFmt)).Set(ctx, standings)
+	if err != nil {
+		s.WarningMsg("error persisting the standings snapshot to Firebase", err)
+	}
+	return &standings
+}
+
+// previousGames returns the game data last persisted to doc, migrated to the
+// current schema. A missing doc is an empty AllSpark
+func previousGames(ctx context.Context, doc *firestore.DocumentRef) (transformers.AllSpark, error) {
+	prev := transformers.AllSpark{}
+	snap, err := doc.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return prev, nil
+	}
+	if err != nil {
+		return prev, err
+	}
+	err = snap.DataTo(&prev)
+	return transformers.Migrate(prev), err
+}
+
+// dayDoc returns the doc of a day's game data, for a date as StatsAPI formats it
+func (s Service) dayDoc(day string) (*firestore.DocumentRef, error) {
+	date, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return nil, err
+	}
+	return s.FirestoreClient.Collection(s.DBCollection).Doc(date.Format(s.DateFmt)), nil
+}
+
+// previousDays returns the game data last persisted for each of days, keyed
+// the same way and migrated to the current schema. Missing docs are left out
+func (s Service) previousDays(ctx context.Context, days map[string]transformers.AllSpark) (map[string]transformers.AllSpark, error) {
+	prevs := make(map[string]transformers.AllSpark, len(days))
+	dayOf := make(map[string]string, len(days))
+	docs := make([]*firestore.DocumentRef, 0, len(days))
+	for day := range days {
+		doc, err := s.dayDoc(day)
+		if err != nil {
+			return prevs, err
+		}
+		dayOf[doc.ID] = day
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return prevs, nil
+	}
+	snaps, err := s.FirestoreClient.GetAll(ctx, docs)
+	if err != nil {
+		return prevs, err
+	}
+	for _, snap := range snaps {
+		if !snap.Exists() {
+			continue
+		}
+		prev := transformers.AllSpark{}
+		err = snap.DataTo(&prev)
+		if err != nil {
+			return prevs, err
+		}
+		prevs[dayOf[snap.Ref.ID]] = transformers.Migrate(prev)
+	}
+	return prevs, nil
+}
+
+// fileDays files the games of each processed day under the baseball days they
+// belong to, see transformers.FileGames, and persists every day they touch.
+// Processed days replace their doc, keeping games filed there from other
+// days, and other days have their games merged into what they had. Every
+// filed day is returned, keyed by date as StatsAPI formats it
+func (s Service) fileDays(ctx context.Context, days, prevs map[string]transformers.AllSpark) (map[string]transformers.AllSpark, error) {
+	filed := transformers.FileGames(days)
+
+	others := map[string]transformers.AllSpark{}
+	for day, spark := range filed {
+		if _, ok := days[day]; !ok {
+			others[day] = spark
+		}
+	}
+	otherPrevs, err := s.previousDays(ctx, others)
+	if err != nil {
+		return filed, err
+	}
+
+	batch := s.FirestoreClient.Batch()
+	for day, spark := range filed {
+		if _, ok := days[day]; ok {
+			spark = transformers.MergeGames(transformers.Refiled(prevs[day], day), spark)
+		} else {
+			spark = transformers.MergeGames(otherPrevs[day], spark)
+		}
+		filed[day] = spark
+		doc, err := s.dayDoc(day)
+		if err != nil {
+			return filed, err
+		}
+		batch.Set(doc, spark)
+	}
+	if len(filed) == 0 {
+		return filed, nil
+	}
+	_, err = batch.Commit(ctx)
+	return filed, err
+}
+
+// handleStatsAPIError responds to a failed StatsAPI request, only alerting when
+// the failure needs attention
+func (s Service) handleStatsAPIError(w http.ResponseWriter, msg string, err error) {
+	code, alert := StatsAPIErrorResponse(err)
+	if alert {
+		s.HandleError(w, code, msg, err)
+		return
+	}
+	s.HandleWarning(w, code, msg, err)
+}
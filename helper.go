@@ -1,35 +1,144 @@
 package function
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"time"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+	"github.com/unrealities/warning-track-backend/transformers"
 )
 
-// ParseDate parses the request body and returns a time.Time value of the requested date
-func ParseDate(reqBody io.ReadCloser, dateFormat string) (time.Time, error) {
+// standingsCollectionSuffix is appended to a game data collection to name its standings collection
+const standingsCollectionSuffix = "-standings"
+
+// requestTimeout bounds a GetGameDataByDay call, including StatsAPI retries, so
+// it still responds within the 10s function timeout set in cloudbuild.json
+const requestTimeout = 9 * time.Second
+
+// referenceTimeout bounds reading and refreshing the season's reference data,
+// which can take a request per team when rosters are stale
+const referenceTimeout = 2 * time.Second
+
+// enrichTimeout bounds enriching a day's live games, which takes up to three
+// StatsAPI requests per game
+const enrichTimeout = 3 * time.Second
+
+// maxDateRangeDays is the longest date range that can be requested at once
+const maxDateRangeDays = 14
+
+// Request is the data requested in the body of a GetGameDataByDay call
+type Request struct {
+	Date          time.Time
+	EndDate       time.Time
+	PostalCode    string
+	SchemaVersion int
+	Sport         mlbstats.SportID
+}
+
+// ParseRequest parses the request body. The date defaults to the current
+// baseball day, the end date, for a range of days, defaults to the date and the
+// sport defaults to MLB. A postal code adds each game's MLB.TV availability
+// there to the response. The schema version of the response defaults to
+// transformers.LegacySchemaVersion, which every client before versioning understands
+func ParseRequest(reqBody io.ReadCloser, dateFormat string) (Request, error) {
 	type d struct {
-		Date string `json:"date"`
+		Date          string `json:"date"`
+		EndDate       string `json:"endDate"`
+		PostalCode    string `json:"postalCode"`
+		SchemaVersion *int   `json:"schemaVersion"`
+		Sport         string `json:"sport"`
 	}
 	type data struct {
 		Data d `json:"data"`
 	}
 	var cont data
 
-	// Default if date cannot be determined
-	tz, err := time.LoadLocation("America/Los_Angeles")
+	// Default to the current baseball day if date cannot be determined
+	defaultDate, err := transformers.BaseballDay(time.Now())
 	if err != nil {
-		return time.Time{}, err
+		return Request{}, err
 	}
-	defaultDate := time.Now().In(tz)
+	req := Request{Date: defaultDate, EndDate: defaultDate, SchemaVersion: transformers.LegacySchemaVersion, Sport: mlbstats.SportMLB}
 
 	err = json.NewDecoder(reqBody).Decode(&cont)
 	if err != nil {
-		return defaultDate, nil
+		return req, nil
+	}
+
+	if cont.Data.Date != "" {
+		req.Date, err = time.Parse(dateFormat, cont.Data.Date)
+		if err != nil {
+			return Request{}, err
+		}
+		req.EndDate = req.Date
 	}
-	if cont.Data.Date == "" {
-		return defaultDate, nil
+
+	if cont.Data.EndDate != "" {
+		req.EndDate, err = time.Parse(dateFormat, cont.Data.EndDate)
+		if err != nil {
+			return Request{}, err
+		}
+		if req.EndDate.Before(req.Date) {
+			return Request{}, fmt.Errorf("endDate %s is before date %s", cont.Data.EndDate, req.Date.Format(dateFormat))
+		}
+		if req.EndDate.Sub(req.Date) >= maxDateRangeDays*24*time.Hour {
+			return Request{}, fmt.Errorf("date ranges are limited to %d days", maxDateRangeDays)
+		}
 	}
 
-	return time.Parse(dateFormat, cont.Data.Date)
+	if cont.Data.Sport != "" {
+		req.Sport, err = mlbstats.ParseSportID(cont.Data.Sport)
+		if err != nil {
+			return Request{}, err
+		}
+	}
+
+	req.PostalCode = cont.Data.PostalCode
+
+	if v := cont.Data.SchemaVersion; v != nil {
+		if *v < 0 || *v > transformers.SchemaVersion {
+			return Request{}, fmt.Errorf("schemaVersion %d is not between 0 and %d", *v, transformers.SchemaVersion)
+		}
+		req.SchemaVersion = *v
+	}
+
+	return req, nil
+}
+
+// SportCollection returns the Firestore collection for a sport's game data.
+// MLB keeps the base collection, other sports are suffixed with their slug
+func SportCollection(baseCollection string, sport mlbstats.SportID) string {
+	if sport == mlbstats.SportMLB {
+		return baseCollection
+	}
+	return baseCollection + "-" + sport.String()
+}
+
+// StatsAPIErrorResponse maps an error from mlbstats to the HTTP status code to
+// respond with and whether it should raise an error report
+func StatsAPIErrorResponse(err error) (code int, alert bool) {
+	var emptyErr *mlbstats.EmptyScheduleError
+	var statusErr *mlbstats.StatusError
+	var decodeErr *mlbstats.DecodeError
+
+	switch {
+	case errors.As(err, &emptyErr):
+		return http.StatusNotFound, false
+	case errors.Is(err, mlbstats.ErrCircuitOpen):
+		return http.StatusServiceUnavailable, false
+	case errors.As(err, &statusErr) && statusErr.Temporary():
+		return http.StatusServiceUnavailable, true
+	case errors.As(err, &statusErr):
+		return http.StatusBadGateway, true
+	case errors.As(err, &decodeErr):
+		return http.StatusBadGateway, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, true
+	}
+	return http.StatusBadGateway, true
 }
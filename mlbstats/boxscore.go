@@ -0,0 +1,131 @@
+package mlbstats
+
+import "strconv"
+
+// BattingStats is a player's or team's batting line
+type BattingStats struct {
+	AtBats           int64  `json:"atBats"`
+	Avg              string `json:"avg"`
+	BaseOnBalls      int64  `json:"baseOnBalls"`
+	Doubles          int64  `json:"doubles"`
+	HitByPitch       int64  `json:"hitByPitch"`
+	Hits             int64  `json:"hits"`
+	HomeRuns         int64  `json:"homeRuns"`
+	LeftOnBase       int64  `json:"leftOnBase"`
+	Obp              string `json:"obp"`
+	Ops              string `json:"ops"`
+	PlateAppearances int64  `json:"plateAppearances"`
+	Rbi              int64  `json:"rbi"`
+	Runs             int64  `json:"runs"`
+	Slg              string `json:"slg"`
+	StolenBases      int64  `json:"stolenBases"`
+	StrikeOuts       int64  `json:"strikeOuts"`
+	Summary          string `json:"summary"`
+	Triples          int64  `json:"triples"`
+}
+
+// Boxscore is the format of the json returned from the game boxscore endpoint
+type Boxscore struct {
+	Copyright     string          `json:"copyright"`
+	Info          []BoxscoreLabel `json:"info"`
+	Officials     []struct{}      `json:"officials"`
+	PitchingNotes []string        `json:"pitchingNotes"`
+	Teams         struct {
+		Away BoxscoreTeam `json:"away"`
+		Home BoxscoreTeam `json:"home"`
+	} `json:"teams"`
+	TopPerformers []struct{} `json:"topPerformers"`
+}
+
+// BoxscoreLabel is a labeled line of boxscore notes, e.g. "Pitches-strikes"
+type BoxscoreLabel struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// BoxscorePlayer is a single player's part in a game
+type BoxscorePlayer struct {
+	AllPositions []Position `json:"allPositions"`
+	BattingOrder string     `json:"battingOrder"`
+	GameStatus   struct {
+		IsCurrentBatter  bool `json:"isCurrentBatter"`
+		IsCurrentPitcher bool `json:"isCurrentPitcher"`
+		IsOnBench        bool `json:"isOnBench"`
+		IsSubstitute     bool `json:"isSubstitute"`
+	} `json:"gameStatus"`
+	JerseyNumber string          `json:"jerseyNumber"`
+	ParentTeamID int64           `json:"parentTeamId"`
+	Person       Player          `json:"person"`
+	Position     Position        `json:"position"`
+	SeasonStats  BoxscoreStats   `json:"seasonStats"`
+	Stats        BoxscoreStats   `json:"stats"`
+	Status       CodeDescription `json:"status"`
+}
+
+// BoxscoreStats is a player's or team's batting and pitching lines
+type BoxscoreStats struct {
+	Batting  BattingStats  `json:"batting"`
+	Fielding struct{}      `json:"fielding"`
+	Pitching PitchingStats `json:"pitching"`
+}
+
+// BoxscoreTeam is one side of a Boxscore. Players are keyed "ID" + their person ID
+type BoxscoreTeam struct {
+	Batters      []int64                   `json:"batters"`
+	BattingOrder []int64                   `json:"battingOrder"`
+	Bench        []int64                   `json:"bench"`
+	Bullpen      []int64                   `json:"bullpen"`
+	Info         []struct{}                `json:"info"`
+	Note         []BoxscoreLabel           `json:"note"`
+	Pitchers     []int64                   `json:"pitchers"`
+	Players      map[string]BoxscorePlayer `json:"players"`
+	Team         Team                      `json:"team"`
+	TeamStats    BoxscoreStats             `json:"teamStats"`
+}
+
+// PitchingStats is a player's or team's pitching line
+type PitchingStats struct {
+	BaseOnBalls       int64  `json:"baseOnBalls"`
+	BattersFaced      int64  `json:"battersFaced"`
+	EarnedRuns        int64  `json:"earnedRuns"`
+	Era               string `json:"era"`
+	GamesStarted      int64  `json:"gamesStarted"`
+	Hits              int64  `json:"hits"`
+	HomeRuns          int64  `json:"homeRuns"`
+	InningsPitched    string `json:"inningsPitched"`
+	Losses            int64  `json:"losses"`
+	Note              string `json:"note"`
+	NumberOfPitches   int64  `json:"numberOfPitches"`
+	Outs              int64  `json:"outs"`
+	PitchesThrown     int64  `json:"pitchesThrown"`
+	Runs              int64  `json:"runs"`
+	Saves             int64  `json:"saves"`
+	StrikeOuts        int64  `json:"strikeOuts"`
+	Strikes           int64  `json:"strikes"`
+	StrikeoutsPer9Inn string `json:"strikeoutsPer9Inn"`
+	Summary           string `json:"summary"`
+	Whip              string `json:"whip"`
+	Wins              int64  `json:"wins"`
+}
+
+// Position is a fielding position
+type Position struct {
+	Abbreviation string `json:"abbreviation"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+}
+
+// Player returns the team's player with the given person ID
+func (t BoxscoreTeam) Player(id int64) (BoxscorePlayer, bool) {
+	p, ok := t.Players["ID"+strconv.FormatInt(id, 10)]
+	return p, ok
+}
+
+// Starter returns the team's starting pitcher
+func (t BoxscoreTeam) Starter() (BoxscorePlayer, bool) {
+	if len(t.Pitchers) == 0 {
+		return BoxscorePlayer{}, false
+	}
+	return t.Player(t.Pitchers[0])
+}
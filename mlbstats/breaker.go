@@ -0,0 +1,137 @@
+package mlbstats
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting StatsAPI while the circuit breaker is open
+var ErrCircuitOpen = errors.New("mlbStats: StatsAPI circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState int
+
+// The CircuitBreaker states
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker fails StatsAPI requests fast after repeated upstream failures.
+// After Cooldown a single trial request is let through; its outcome closes or
+// re-opens the breaker
+type CircuitBreaker struct {
+	Cooldown  time.Duration
+	Logf      func(format string, v ...interface{})
+	Threshold int
+
+	failures int
+	mu       sync.Mutex
+	openedAt time.Time
+	state    BreakerState
+	trial    bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Cooldown: cooldown, Threshold: threshold}
+}
+
+// Allow returns ErrCircuitOpen if a request should not be attempted
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.Cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(BreakerHalfOpen)
+		b.trial = true
+		return nil
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Success records a healthy StatsAPI response
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+	if b.state != BreakerClosed {
+		b.setState(BreakerClosed)
+	}
+}
+
+// Failure records an upstream failure
+func (b *CircuitBreaker) Failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.Threshold) {
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// Abandon records a request that ended without a verdict on StatsAPI's health,
+// such as one cancelled by the caller
+func (b *CircuitBreaker) Abandon() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// State returns the current BreakerState
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// setState transitions the breaker and logs the change. b.mu must be held
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.Logf != nil {
+		b.Logf("mlbStats: StatsAPI circuit breaker %s -> %s (consecutive failures: %d)", b.state, state, b.failures)
+	}
+	b.state = state
+}
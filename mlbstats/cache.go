@@ -0,0 +1,292 @@
+package mlbstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The default Cache TTLs. Live games change every pitch, scheduled games
+// rarely and final games not at all
+const (
+	DefaultCacheTTL      = time.Minute
+	DefaultCacheFinalTTL = 3 * time.Hour
+	DefaultCacheLiveTTL  = 10 * time.Second
+)
+
+// DefaultCacheMaxBytes bounds the total size of the response bodies a Cache
+// made by NewCache keeps. Schedules and live feeds vary from a few KB to a few
+// MB, so counting entries would not bound the function's memory
+const DefaultCacheMaxBytes = 16 << 20
+
+// errNotModified is returned by fetch when StatsAPI confirms a cached response is current
+var errNotModified = errors.New("mlbStats: not modified")
+
+// Cache is an in-process cache of StatsAPI responses shared by every request
+// a Client makes. Identical requests in flight at the same time are coalesced
+// into one, responses stay fresh for a TTL that depends on the state of their
+// games, and stale responses are revalidated with If-None-Match when StatsAPI
+// sent an ETag
+type Cache struct {
+	DefaultTTL time.Duration
+	FinalTTL   time.Duration
+	LiveTTL    time.Duration
+	MaxBytes   int
+
+	entries  map[string]*cacheEntry
+	inflight map[string]*cacheCall
+	mu       sync.Mutex
+	size     int
+}
+
+// cacheEntry is a cached response body, already filtered of skipped fields,
+// and the schema drift found in the response as StatsAPI sent it
+type cacheEntry struct {
+	body    []byte
+	drift   *DriftReport
+	etag    string
+	expires time.Time
+}
+
+// cacheCall is a request in flight that other callers wait on
+type cacheCall struct {
+	body        []byte
+	done        chan struct{}
+	drift       *DriftReport
+	err         error
+	uncacheable bool
+}
+
+// cacheBuffer collects a response body for the Cache, giving up once it grows
+// past limit so an oversized response is never held twice in memory
+type cacheBuffer struct {
+	bytes.Buffer
+	limit      int
+	overflowed bool
+}
+
+func (b *cacheBuffer) Write(p []byte) (int, error) {
+	if b.overflowed {
+		return len(p), nil
+	}
+	if b.limit > 0 && b.Len()+len(p) > b.limit {
+		b.overflowed = true
+		b.Buffer = bytes.Buffer{}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// Reset empties the buffer for another attempt
+func (b *cacheBuffer) Reset() {
+	b.Buffer.Reset()
+	b.overflowed = false
+}
+
+// cacheTTLer is implemented by the responses a Cache stores, which know how
+// long they stay fresh
+type cacheTTLer interface {
+	cacheTTL(c *Cache) time.Duration
+}
+
+// NewCache returns a Cache with the default TTLs
+func NewCache() *Cache {
+	return &Cache{
+		DefaultTTL: DefaultCacheTTL,
+		FinalTTL:   DefaultCacheFinalTTL,
+		LiveTTL:    DefaultCacheLiveTTL,
+		MaxBytes:   DefaultCacheMaxBytes,
+	}
+}
+
+// cachedGet serves the GET request for URL from c.Cache, joining an identical
+// request in flight or making the request when the cached response is stale.
+// Callers that joined a request cancelled by its own caller's ctx try again
+func (c *Client) cachedGet(ctx context.Context, URL string, v cacheTTLer) error {
+	cache := c.Cache
+
+	for {
+		cache.mu.Lock()
+		entry := cache.entries[URL]
+		if entry != nil && time.Now().Before(entry.expires) {
+			cache.mu.Unlock()
+			return decodeCached(ctx, URL, entry.body, entry.drift, v)
+		}
+		call, ok := cache.inflight[URL]
+		if !ok {
+			call = &cacheCall{done: make(chan struct{})}
+			if cache.inflight == nil {
+				cache.inflight = map[string]*cacheCall{}
+			}
+			cache.inflight[URL] = call
+			cache.mu.Unlock()
+			return c.fillCache(ctx, URL, v, entry, call)
+		}
+		cache.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return fmt.Errorf("mlbStats#Client: Get %s: %w", URL, ctx.Err())
+		}
+		switch {
+		case call.err != nil && isContextError(call.err) && ctx.Err() == nil:
+			continue
+		case call.err != nil:
+			return call.err
+		case call.uncacheable:
+			_, err := c.request(ctx, URL, v, fetchOptions{})
+			return err
+		}
+		return decodeCached(ctx, URL, call.body, call.drift, v)
+	}
+}
+
+// fillCache makes the request for call, storing the response unless it is
+// larger than the whole cache. entry is the stale response, if any
+func (c *Client) fillCache(ctx context.Context, URL string, v cacheTTLer, entry *cacheEntry, call *cacheCall) error {
+	cache := c.Cache
+
+	// The drift of the response as StatsAPI sent it is kept with the entry,
+	// so strict callers served from the cache still see it
+	var drift *DriftReport
+	report := driftReportFrom(ctx)
+	fetchCtx := ctx
+	if report != nil {
+		drift = &DriftReport{}
+		fetchCtx = WithDriftReport(ctx, drift)
+	}
+
+	opts := fetchOptions{body: &cacheBuffer{limit: cache.MaxBytes}}
+	if entry != nil {
+		opts.etag = entry.etag
+	}
+	etag, err := c.request(fetchCtx, URL, v, opts)
+	body := opts.body.Bytes()
+	uncacheable := opts.body.overflowed
+	if errors.Is(err, errNotModified) {
+		etag = entry.etag
+		body = entry.body
+		drift = entry.drift
+		uncacheable = false
+		err = decodeCached(ctx, URL, body, drift, v)
+	} else if report != nil {
+		report.merge(drift)
+	}
+
+	cache.mu.Lock()
+	delete(cache.inflight, URL)
+	if err == nil && !uncacheable {
+		cache.store(URL, &cacheEntry{body: body, drift: drift, etag: etag, expires: time.Now().Add(v.cacheTTL(cache))})
+	}
+	cache.mu.Unlock()
+
+	call.body = body
+	call.drift = drift
+	call.err = err
+	call.uncacheable = uncacheable
+	close(call.done)
+	return err
+}
+
+// isContextError reports if err is from a cancelled or expired ctx
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// store adds an entry, evicting expired entries and then the soonest to
+// expire to stay within MaxBytes. cache.mu must be held
+func (cache *Cache) store(URL string, entry *cacheEntry) {
+	if cache.entries == nil {
+		cache.entries = map[string]*cacheEntry{}
+	}
+	if old, ok := cache.entries[URL]; ok {
+		cache.size -= len(old.body)
+		delete(cache.entries, URL)
+	}
+	if cache.MaxBytes > 0 {
+		if len(entry.body) > cache.MaxBytes {
+			return
+		}
+		if cache.size+len(entry.body) > cache.MaxBytes {
+			now := time.Now()
+			for u, e := range cache.entries {
+				if now.After(e.expires) {
+					cache.size -= len(e.body)
+					delete(cache.entries, u)
+				}
+			}
+		}
+		for cache.size+len(entry.body) > cache.MaxBytes {
+			oldest := ""
+			for u, e := range cache.entries {
+				if oldest == "" || e.expires.Before(cache.entries[oldest].expires) {
+					oldest = u
+				}
+			}
+			cache.size -= len(cache.entries[oldest].body)
+			delete(cache.entries, oldest)
+		}
+	}
+	cache.entries[URL] = entry
+	cache.size += len(entry.body)
+}
+
+// decodeCached decodes a cached response body into v. Strict callers get the
+// drift recorded when the body was fetched, or a check of the cached body when
+// it was fetched without strict decoding
+func decodeCached(ctx context.Context, URL string, body []byte, drift *DriftReport, v interface{}) error {
+	if report := driftReportFrom(ctx); report != nil {
+		if drift != nil {
+			report.merge(drift)
+		} else {
+			report.check(URL, body, v)
+		}
+	}
+	err := json.Unmarshal(body, v)
+	if err != nil {
+		return fmt.Errorf("mlbStats#Client: %w", newDecodeError(URL, err))
+	}
+	return nil
+}
+
+// cacheTTL is LiveTTL while any game is live, FinalTTL once every game is
+// settled and DefaultTTL otherwise
+func (s *Schedule) cacheTTL(c *Cache) time.Duration {
+	ttl := c.FinalTTL
+	for _, d := range s.Dates {
+		for _, g := range d.Games {
+			switch {
+			case g.Status.IsLive():
+				return c.LiveTTL
+			case !g.Status.settled():
+				ttl = c.DefaultTTL
+			}
+		}
+	}
+	return ttl
+}
+
+// cacheTTL is LiveTTL while the game is live, FinalTTL once it is settled and DefaultTTL otherwise
+func (f *LiveFeed) cacheTTL(c *Cache) time.Duration {
+	switch {
+	case f.GameData.Status.IsLive():
+		return c.LiveTTL
+	case f.GameData.Status.settled():
+		return c.FinalTTL
+	}
+	return c.DefaultTTL
+}
+
+// settled reports if nothing more will happen to the game on its scheduled day
+func (s Status) settled() bool {
+	switch s.Phase() {
+	case PhaseFinal, PhaseGameOver, PhaseForfeit, PhasePostponed, PhaseCancelled, PhaseSuspended:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package mlbstats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client against srv that makes a single attempt per request
+func newTestClient(srv *httptest.Server) *Client {
+	c := NewClient()
+	c.BaseURL = srv.URL
+	c.HTTPClient = srv.Client()
+	c.Retry.MaxAttempts = 1
+	return c
+}
+
+func TestCacheMaxBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"copyright":%q,"dates":[]}`, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	c.Cache.MaxBytes = 300
+	for i := 0; i < 5; i++ {
+		if err := c.get(context.Background(), fmt.Sprintf("/schedule/%d", i), &Schedule{}); err != nil {
+			t.Fatalf("get() = %v", err)
+		}
+	}
+	if c.Cache.size > c.Cache.MaxBytes {
+		t.Errorf("cache size = %d, want at most %d", c.Cache.size, c.Cache.MaxBytes)
+	}
+	if len(c.Cache.entries) != 2 {
+		t.Errorf("cached %d entries, want 2", len(c.Cache.entries))
+	}
+
+	c.Cache.MaxBytes = 50
+	if err := c.get(context.Background(), "/schedule/large", &Schedule{}); err != nil {
+		t.Fatalf("get() = %v", err)
+	}
+	if _, ok := c.Cache.entries[srv.URL+"/schedule/large"]; ok {
+		t.Error("cached a response larger than MaxBytes")
+	}
+}
+
+func TestCacheWaiterRetriesCancelledLeader(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"dates":[]}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	URL := srv.URL + "/schedule"
+
+	// A leader whose caller gave up
+	leader := &cacheCall{done: make(chan struct{})}
+	c.Cache.inflight = map[string]*cacheCall{URL: leader}
+
+	errs := make(chan error)
+	go func() {
+		errs <- c.get(context.Background(), "/schedule", &Schedule{})
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	c.Cache.mu.Lock()
+	delete(c.Cache.inflight, URL)
+	c.Cache.mu.Unlock()
+	leader.err = fmt.Errorf("mlbStats#Client: %w", context.Canceled)
+	close(leader.done)
+
+	if err := <-errs; err != nil {
+		t.Fatalf("get() = %v, want the waiter to retry", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
+
+func TestCacheHitReportsDrift(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"dates":[],"newField":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	if err := c.get(context.Background(), "/schedule", &Schedule{}); err != nil {
+		t.Fatalf("get() = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		report := &DriftReport{}
+		ctx := WithDriftReport(context.Background(), report)
+		if err := c.get(ctx, "/schedule", &Schedule{}); err != nil {
+			t.Fatalf("get() = %v", err)
+		}
+		if got := report.UnknownFields(); len(got) != 1 || got[0] != "/schedule newField" {
+			t.Errorf("attempt %d: UnknownFields() = %v, want [/schedule newField]", i, got)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package mlbstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DefaultBaseURL is the host all StatsAPI requests are made against
+const DefaultBaseURL = "https://statsapi.mlb.com"
+
+// DefaultTimeout is the per-request timeout used by NewClient
+const DefaultTimeout = 5 * time.Second
+
+// DefaultUserAgent identifies Warning-Track to StatsAPI
+const DefaultUserAgent = "warning-track-backend"
+
+// Client is the single entry point for StatsAPI requests
+type Client struct {
+	BaseURL         string
+	Breaker         *CircuitBreaker
+	Cache           *Cache
+	HTTPClient      *http.Client
+	MaxResponseSize int64
+	Retry           RetryPolicy
+	SkipFields      []string
+	Timeout         time.Duration
+	UserAgent       string
+}
+
+// NewClient returns a Client configured with the StatsAPI defaults
+func NewClient() *Client {
+	breaker := NewCircuitBreaker(5, 30*time.Second)
+	breaker.Logf = log.Printf
+
+	return &Client{
+		BaseURL:         DefaultBaseURL,
+		Breaker:         breaker,
+		Cache:           NewCache(),
+		HTTPClient:      &http.Client{},
+		MaxResponseSize: DefaultMaxResponseSize,
+		Retry:           DefaultRetryPolicy,
+		SkipFields:      DefaultSkipFields,
+		Timeout:         DefaultTimeout,
+		UserAgent:       DefaultUserAgent,
+	}
+}
+
+// fetchOptions are the optional parts of a single StatsAPI request
+type fetchOptions struct {
+	body *cacheBuffer
+	etag string
+}
+
+// get requests the given StatsAPI path and decodes the json response into v.
+// Responses that know their freshness are served through c.Cache
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	URL := c.BaseURL + path
+
+	if ttler, ok := v.(cacheTTLer); ok && c.Cache != nil {
+		return c.cachedGet(ctx, URL, ttler)
+	}
+	_, err := c.request(ctx, URL, v, fetchOptions{})
+	return err
+}
+
+// request makes the GET request for URL, decoding the response into v, and
+// returns the response's ETag. Transient failures are retried per c.Retry
+// until the ctx deadline
+func (c *Client) request(ctx context.Context, URL string, v interface{}, opts fetchOptions) (string, error) {
+	for attempt := 1; ; attempt++ {
+		err := c.Breaker.Allow()
+		if err != nil {
+			return "", fmt.Errorf("mlbStats#Client: Get %s: %w", URL, err)
+		}
+
+		etag, err := c.fetch(ctx, URL, v, opts)
+		switch {
+		case err == nil:
+			c.Breaker.Success()
+		case retryable(err) && ctx.Err() == nil:
+			c.Breaker.Failure()
+		default:
+			// Cancelled requests and 4xx responses say nothing about StatsAPI's health
+			c.Breaker.Abandon()
+		}
+		if err == nil {
+			return etag, nil
+		}
+
+		if attempt >= c.Retry.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return "", fmt.Errorf("mlbStats#Client: %w", err)
+		}
+		delay := c.Retry.backoff(attempt)
+		if ra := retryAfter(err); ra > delay {
+			delay = ra
+		}
+		if !sleep(ctx, delay) {
+			return "", fmt.Errorf("mlbStats#Client: giving up after %d attempts: %w", attempt, err)
+		}
+	}
+}
+
+// newDecodeError wraps a json error with the byte offset it occurred at
+func newDecodeError(URL string, err error) *DecodeError {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	return &DecodeError{Err: err, Offset: offset, URL: URL}
+}
+
+// fetch makes a single GET request for URL and streams the response body into v,
+// dropping c.SkipFields and reading no more than c.MaxResponseSize bytes. The
+// decoded body is copied to opts.body and errNotModified is returned when
+// StatsAPI confirms opts.etag is still current
+func (c *Client) fetch(ctx context.Context, URL string, v interface{}, opts fetchOptions) (string, error) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("building request for %s, error: %w", URL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if opts.etag != "" {
+		req.Header.Set("If-None-Match", opts.etag)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Get %s, error: %w", URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && opts.etag != "" {
+		return "", errNotModified
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLen+1))
+		return "", &StatusError{
+			Body:       truncate(string(snippet), maxErrorBodyLen),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			StatusCode: resp.StatusCode,
+			URL:        URL,
+		}
+	}
+
+	if c.MaxResponseSize > 0 && resp.ContentLength > c.MaxResponseSize {
+		return "", &ResponseTooLargeError{Limit: c.MaxResponseSize, URL: URL}
+	}
+
+	body := &limitedBody{limit: c.MaxResponseSize, r: resp.Body, URL: URL}
+	var r io.Reader = body
+	// Strict decoding checks the response as StatsAPI sent it, before any skipping
+	var raw bytes.Buffer
+	report := driftReportFrom(ctx)
+	if report != nil {
+		r = io.TeeReader(r, &raw)
+	}
+	if len(c.SkipFields) > 0 {
+		r = newSkipReader(r, c.SkipFields)
+	}
+	if opts.body != nil {
+		opts.body.Reset()
+		r = io.TeeReader(r, opts.body)
+	}
+
+	err = json.NewDecoder(r).Decode(v)
+	if body.err != nil {
+		return "", fmt.Errorf("reading Get %s response body error: %w", URL, body.err)
+	}
+	if report != nil {
+		report.check(URL, raw.Bytes(), v)
+	}
+	if err != nil {
+		return "", newDecodeError(URL, err)
+	}
+
+	return resp.Header.Get("ETag"), nil
+}
@@ -0,0 +1,245 @@
+package mlbstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DriftReport records where StatsAPI responses no longer match the mlbstats
+// models: fields we don't model, fields of an unexpected json type and
+// required fields (tagged `statsapi:"required"`) that are missing
+type DriftReport struct {
+	missing    map[string]bool
+	mismatches map[string]bool
+	mu         sync.Mutex
+	unknown    map[string]bool
+}
+
+// driftReportKey is the context key of the DriftReport being collected
+type driftReportKey struct{}
+
+// WithDriftReport returns a ctx that opts StatsAPI requests made with it into
+// strict decoding. Any schema drift found is recorded in r
+func WithDriftReport(ctx context.Context, r *DriftReport) context.Context {
+	return context.WithValue(ctx, driftReportKey{}, r)
+}
+
+// driftReportFrom returns the DriftReport collected for ctx, if any
+func driftReportFrom(ctx context.Context) *DriftReport {
+	r, _ := ctx.Value(driftReportKey{}).(*DriftReport)
+	return r
+}
+
+// Empty reports if no drift was found
+func (r *DriftReport) Empty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.missing) == 0 && len(r.mismatches) == 0 && len(r.unknown) == 0
+}
+
+// MissingFields returns the required fields that were absent or null
+func (r *DriftReport) MissingFields() []string {
+	return r.list(&r.missing)
+}
+
+// TypeMismatches returns the fields whose json type did not match the model
+func (r *DriftReport) TypeMismatches() []string {
+	return r.list(&r.mismatches)
+}
+
+// UnknownFields returns the fields StatsAPI sent that are not modeled
+func (r *DriftReport) UnknownFields() []string {
+	return r.list(&r.unknown)
+}
+
+// maxDriftErrorEntries bounds the entries of each kind listed by DriftReport.Error.
+// Subset models like Boxscore leave many fields unknown, which would otherwise
+// flood error reporting
+const maxDriftErrorEntries = 10
+
+// Error summarizes the report so it can be sent to error reporting
+func (r *DriftReport) Error() string {
+	var parts []string
+	if missing := r.MissingFields(); len(missing) > 0 {
+		parts = append(parts, "missing required fields: "+summarizeDrift(missing))
+	}
+	if mismatches := r.TypeMismatches(); len(mismatches) > 0 {
+		parts = append(parts, "type mismatches: "+summarizeDrift(mismatches))
+	}
+	if unknown := r.UnknownFields(); len(unknown) > 0 {
+		parts = append(parts, "unknown fields: "+summarizeDrift(unknown))
+	}
+	return "mlbStats: StatsAPI schema drift: " + strings.Join(parts, "; ")
+}
+
+// summarizeDrift lists up to maxDriftErrorEntries entries and counts the rest
+func summarizeDrift(entries []string) string {
+	if len(entries) <= maxDriftErrorEntries {
+		return strings.Join(entries, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(entries[:maxDriftErrorEntries], ", "), len(entries)-maxDriftErrorEntries)
+}
+
+// list returns the sorted entries of one of the report's sets
+func (r *DriftReport) list(set *map[string]bool) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]string, 0, len(*set))
+	for e := range *set {
+		entries = append(entries, e)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// add records an entry in one of the report's sets
+func (r *DriftReport) add(set *map[string]bool, entry string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if *set == nil {
+		*set = map[string]bool{}
+	}
+	(*set)[entry] = true
+}
+
+// merge records every entry of other in r
+func (r *DriftReport) merge(other *DriftReport) {
+	for _, e := range other.MissingFields() {
+		r.add(&r.missing, e)
+	}
+	for _, e := range other.TypeMismatches() {
+		r.add(&r.mismatches, e)
+	}
+	for _, e := range other.UnknownFields() {
+		r.add(&r.unknown, e)
+	}
+}
+
+// check compares a json response body from URL against the model v decodes into
+func (r *DriftReport) check(URL string, body []byte, v interface{}) {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return
+	}
+	endpoint := URL
+	if u, err := url.Parse(URL); err == nil {
+		endpoint = u.Path
+	}
+	r.walk(endpoint+" ", raw, reflect.TypeOf(v))
+}
+
+// walk records drift between a decoded json value and the Go type it maps to.
+// Array elements share the path segment [] so each drift is reported once
+func (r *DriftReport) walk(path string, raw interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if raw == nil {
+		return
+	}
+
+	mismatch := func(expected string) {
+		r.add(&r.mismatches, fmt.Sprintf("%s (expected %s, got %T)", strings.TrimSuffix(path, "."), expected, raw))
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			mismatch("object")
+			return
+		}
+		fields := jsonFields(t)
+		for key, value := range obj {
+			f, ok := fields[key]
+			if !ok {
+				r.add(&r.unknown, path+key)
+				continue
+			}
+			r.walk(path+key+".", value, f.Type)
+		}
+		for key, f := range fields {
+			if f.Tag.Get("statsapi") == "required" && obj[key] == nil {
+				r.add(&r.missing, path+key)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return // json.RawMessage
+		}
+		arr, ok := raw.([]interface{})
+		if !ok {
+			mismatch("array")
+			return
+		}
+		for _, value := range arr {
+			r.walk(strings.TrimSuffix(path, ".")+"[].", value, t.Elem())
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			mismatch("object")
+			return
+		}
+		for _, value := range obj {
+			r.walk(path+"*.", value, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := raw.(string); !ok {
+			mismatch("string")
+		}
+	case reflect.Bool:
+		if _, ok := raw.(bool); !ok {
+			mismatch("bool")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := raw.(float64)
+		if !ok {
+			mismatch("number")
+		} else if n != float64(int64(n)) {
+			mismatch("integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := raw.(float64); !ok {
+			mismatch("number")
+		}
+	}
+}
+
+// jsonFields returns a struct's fields keyed by their json name
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		// Untagged embedded structs have their fields promoted, as encoding/json does
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n, ef := range jsonFields(f.Type) {
+				if _, ok := fields[n]; !ok {
+					fields[n] = ef
+				}
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
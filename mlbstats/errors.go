@@ -0,0 +1,69 @@
+package mlbstats
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxErrorBodyLen is how much of an unexpected response body is kept on a StatusError
+const maxErrorBodyLen = 512
+
+// StatusError is returned when StatsAPI responds with a non-2xx status
+type StatusError struct {
+	Body       string
+	RetryAfter time.Duration
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Get %s: unexpected status %d %s: %q", e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Temporary reports if StatsAPI may succeed when the request is tried again
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// DecodeError is returned when a StatsAPI response body is not the expected json
+type DecodeError struct {
+	Err    error
+	Offset int64
+	URL    string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %s at byte offset %d: %s", e.URL, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseTooLargeError is returned when a StatsAPI response body exceeds Client.MaxResponseSize
+type ResponseTooLargeError struct {
+	Limit int64
+	URL   string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("Get %s: response body larger than %d bytes", e.URL, e.Limit)
+}
+
+// EmptyScheduleError is returned when a schedule has no dates, e.g. an off-day
+type EmptyScheduleError struct {
+	Date time.Time
+}
+
+func (e *EmptyScheduleError) Error() string {
+	return fmt.Sprintf("there are no dates in the schedule for %s", e.Date.Format("2006-01-02"))
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,228 @@
+package mlbstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FeedTracker keeps an in-memory LiveFeed of a single game current. Rather than
+// downloading the full feed on every Update it asks StatsAPI for the json
+// patches since the feed's timecode, falling back to a full fetch when the
+// patch chain breaks. Only the feed's json is kept between updates: decoded,
+// as a LiveFeed or as the tree patches apply to, a late game feed takes
+// several times as much memory
+type FeedTracker struct {
+	GamePk int64
+
+	client   *Client
+	mu       sync.Mutex
+	raw      []byte
+	timecode string
+}
+
+// NewFeedTracker returns a FeedTracker for the given game. No request is made
+// until Update is called
+func NewFeedTracker(c *Client, gamePk int64) *FeedTracker {
+	return &FeedTracker{GamePk: gamePk, client: c}
+}
+
+// Feed returns the last LiveFeed fetched by Update
+func (t *FeedTracker) Feed() LiveFeed {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	feed, _ := t.decode()
+	return feed
+}
+
+// Update brings the tracked LiveFeed up to date and returns it
+func (t *FeedTracker) Update(ctx context.Context) (LiveFeed, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.raw == nil || t.timecode == "" {
+		return t.fullFetch(ctx)
+	}
+
+	timestamps, err := t.client.GetLiveFeedTimestamps(ctx, t.GamePk)
+	if err != nil {
+		return t.last(fmt.Errorf("mlbStats#FeedTracker.Update: %w", err))
+	}
+	if len(timestamps) > 0 && timestamps[len(timestamps)-1] == t.timecode {
+		return t.decode()
+	}
+	if !containsString(timestamps, t.timecode) {
+		// Our timecode is no longer known to StatsAPI, so no patch can reach it
+		return t.fullFetch(ctx)
+	}
+
+	var raw json.RawMessage
+	err = t.client.get(ctx, statsAPILiveFeedDiffPatchPath(t.GamePk, t.timecode), &raw)
+	if err != nil {
+		return t.last(fmt.Errorf("mlbStats#FeedTracker.Update: %w", err))
+	}
+
+	// When too much has changed StatsAPI responds with the full feed instead of patches
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		feed, err := t.load(raw)
+		if err != nil {
+			return t.fullFetch(ctx)
+		}
+		return feed, nil
+	}
+
+	var patches []struct {
+		Diff []PatchOperation `json:"diff"`
+	}
+	err = json.Unmarshal(raw, &patches)
+	if err != nil {
+		return t.fullFetch(ctx)
+	}
+
+	// The tree patches apply to only lives for this update
+	var doc interface{}
+	err = json.Unmarshal(t.raw, &doc)
+	if err != nil {
+		return t.fullFetch(ctx)
+	}
+	for _, p := range patches {
+		doc, err = applyPatch(doc, p.Diff)
+		if err != nil {
+			return t.fullFetch(ctx)
+		}
+	}
+	patched, err := json.Marshal(doc)
+	if err != nil {
+		return t.fullFetch(ctx)
+	}
+	feed, err := t.load(patched)
+	if err != nil {
+		return t.fullFetch(ctx)
+	}
+	return feed, nil
+}
+
+// fullFetch replaces the tracked feed with a fresh download. On failure the
+// last tracked feed is kept. t.mu must be held
+func (t *FeedTracker) fullFetch(ctx context.Context) (LiveFeed, error) {
+	var raw json.RawMessage
+	err := t.client.get(ctx, statsAPILiveFeedPath(t.GamePk), &raw)
+	if err != nil {
+		return t.last(fmt.Errorf("mlbStats#FeedTracker.Update: %w", err))
+	}
+
+	feed, err := t.load(raw)
+	if err != nil {
+		return t.last(fmt.Errorf("mlbStats#FeedTracker.Update: %w", err))
+	}
+	return feed, nil
+}
+
+// load replaces the tracked feed with a full live feed json document of the
+// tracked game and returns it decoded. t.mu must be held
+func (t *FeedTracker) load(raw []byte) (LiveFeed, error) {
+	feed := LiveFeed{}
+	err := json.Unmarshal(raw, &feed)
+	if err != nil {
+		return feed, newDecodeError(statsAPILiveFeedPath(t.GamePk), err)
+	}
+	if feed.GamePk != t.GamePk {
+		return feed, fmt.Errorf("live feed of game %d, want %d", feed.GamePk, t.GamePk)
+	}
+
+	t.raw = append(t.raw[:0:0], raw...)
+	t.timecode = feed.MetaData.TimeStamp
+	return feed, nil
+}
+
+// decode returns the tracked feed as a LiveFeed. t.mu must be held
+func (t *FeedTracker) decode() (LiveFeed, error) {
+	feed := LiveFeed{}
+	if t.raw == nil {
+		return feed, nil
+	}
+	err := json.Unmarshal(t.raw, &feed)
+	if err != nil {
+		return feed, newDecodeError(statsAPILiveFeedPath(t.GamePk), err)
+	}
+	return feed, nil
+}
+
+// last returns the tracked feed alongside err, for updates that failed. t.mu must be held
+func (t *FeedTracker) last(err error) (LiveFeed, error) {
+	feed, _ := t.decode()
+	return feed, err
+}
+
+// containsString reports if s is in list
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedTrackers keeps a FeedTracker per game, so that polls after the first only
+// download patches. Each tracker holds its whole feed in memory, so at most
+// MaxTrackers are kept, evicting the least recently used
+type FeedTrackers struct {
+	MaxTrackers int
+
+	client   *Client
+	mu       sync.Mutex
+	trackers map[int64]*FeedTracker
+	used     map[int64]time.Time
+}
+
+// NewFeedTrackers returns FeedTrackers keeping up to maxTrackers games
+func NewFeedTrackers(c *Client, maxTrackers int) *FeedTrackers {
+	return &FeedTrackers{
+		MaxTrackers: maxTrackers,
+		client:      c,
+		trackers:    map[int64]*FeedTracker{},
+		used:        map[int64]time.Time{},
+	}
+}
+
+// Tracker returns the game's FeedTracker, starting one when it is not tracked
+func (ts *FeedTrackers) Tracker(gamePk int64) *FeedTracker {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.used[gamePk] = time.Now()
+	if t, ok := ts.trackers[gamePk]; ok {
+		return t
+	}
+	for ts.MaxTrackers > 0 && len(ts.trackers) >= ts.MaxTrackers {
+		var oldest int64
+		for pk := range ts.trackers {
+			if oldest == 0 || ts.used[pk].Before(ts.used[oldest]) {
+				oldest = pk
+			}
+		}
+		ts.remove(oldest)
+	}
+	t := NewFeedTracker(ts.client, gamePk)
+	ts.trackers[gamePk] = t
+	return t
+}
+
+// Forget stops tracking the given games, e.g. once they are over
+func (ts *FeedTrackers) Forget(gamePks ...int64) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for _, pk := range gamePks {
+		ts.remove(pk)
+	}
+}
+
+// remove stops tracking a game. ts.mu must be held
+func (ts *FeedTrackers) remove(gamePk int64) {
+	delete(ts.trackers, gamePk)
+	delete(ts.used, gamePk)
+}
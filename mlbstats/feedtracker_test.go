@@ -0,0 +1,318 @@
+package mlbstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lateGameFeed returns a live feed of the given game in the bottom of the 9th,
+// sized like a real one: every pitch with its tracking data and both teams'
+// players with their game and season lines
+func lateGameFeed(tb testing.TB, gamePk int64, timecode string) map[string]interface{} {
+	tb.Helper()
+	player := func(id int) map[string]interface{} {
+		return map[string]interface{}{"id": id, "fullName": fmt.Sprintf("Player %d", id), "link": fmt.Sprintf("/api/v1/people/%d", id)}
+	}
+	pitch := func(play, i int) map[string]interface{} {
+		return map[string]interface{}{
+			"index": i, "pitchNumber": i + 1, "isPitch": true, "type": "pitch",
+			"playId":    fmt.Sprintf("%08x-%04x-4a4b-8c8d-%012x", play, i, play*10+i),
+			"startTime": "2024-06-01T23:15:00.000Z", "endTime": "2024-06-01T23:15:20.000Z",
+			"count": map[string]int{"balls": i % 4, "strikes": i % 3, "outs": 1},
+			"details": map[string]interface{}{
+				"call": map[string]string{"code": "B", "description": "Ball"}, "description": "Ball", "code": "B",
+				"ballColor": "rgba(39, 161, 39, 1.0)", "trailColor": "rgba(188, 0, 33, 1.0)", "isInPlay": false, "isStrike": false, "isBall": true,
+				"type": map[string]string{"code": "FF", "description": "Four-Seam Fastball"}, "isOut": false, "hasReview": false,
+			},
+			"pitchData": map[string]interface{}{
+				"startSpeed": 95.1, "endSpeed": 86.4, "strikeZoneTop": 3.41, "strikeZoneBottom": 1.62,
+				"coordinates": map[string]float64{"aY": 31.2, "aZ": -14.8, "pfxX": -7.1, "pfxZ": 9.8, "pX": 0.41, "pZ": 3.52, "vX0": 8.1, "vY0": -138.4, "vZ0": -6.2, "x": 101.3, "y": 150.9, "x0": -1.9, "y0": 50.0, "z0": 5.8, "aX": -12.1},
+				"breaks":      map[string]float64{"breakAngle": 22.8, "breakLength": 3.6, "breakY": 24.0, "breakVertical": -13.2, "breakVerticalInduced": 17.4, "breakHorizontal": 8.5, "spinRate": 2391, "spinDirection": 211},
+				"zone":        12, "typeConfidence": 0.9, "plateTime": 0.39, "extension": 6.6,
+			},
+		}
+	}
+
+	plays := make([]interface{}, 0, 80)
+	for p := 0; p < 80; p++ {
+		events := make([]interface{}, 4)
+		for i := range events {
+			events[i] = pitch(p, i)
+		}
+		plays = append(plays, map[string]interface{}{
+			"atBatIndex": p,
+			"about":      map[string]interface{}{"atBatIndex": p, "halfInning": "top", "isTopInning": p%2 == 0, "inning": p/9 + 1, "isComplete": true, "isScoringPlay": false, "hasReview": false, "hasOut": true, "captivatingIndex": 14},
+			"count":      map[string]int{"balls": 1, "strikes": 2, "outs": 1},
+			"result":     map[string]interface{}{"type": "atBat", "event": "Groundout", "eventType": "field_out", "description": fmt.Sprintf("Player %d grounds out, shortstop to first baseman.", p), "rbi": 0, "awayScore": 3, "homeScore": 2, "isOut": true},
+			"matchup": map[string]interface{}{
+				"batter": player(600000 + p%9), "batSide": map[string]string{"code": "R", "description": "Right"},
+				"pitcher": player(500000 + p/30), "pitchHand": map[string]string{"code": "R", "description": "Right"},
+				"postOnFirst": player(600000 + (p+8)%9), "splits": map[string]string{"batter": "vs_RHP", "pitcher": "vs_RHB", "menOnBase": "Men_On"},
+			},
+			"pitchIndex": []int{0, 1, 2, 3}, "actionIndex": []int{}, "runnerIndex": []int{0},
+			"runners": []interface{}{map[string]interface{}{
+				"movement": map[string]interface{}{"originBase": nil, "start": nil, "end": nil, "outBase": "1B", "isOut": true, "outNumber": 1},
+				"details":  map[string]interface{}{"event": "Groundout", "eventType": "field_out", "movementReason": nil, "runner": player(600000 + p%9), "responsiblePitcher": nil, "isScoringEvent": false, "rbi": false, "earned": false, "playIndex": 3},
+			}},
+			"playEvents": events, "playEndTime": "2024-06-01T23:16:00.000Z",
+		})
+	}
+
+	stats := map[string]interface{}{
+		"batting":  map[string]interface{}{"atBats": 4, "avg": ".284", "obp": ".351", "slg": ".472", "ops": ".823", "hits": 1, "runs": 0, "rbi": 0, "strikeOuts": 1, "baseOnBalls": 0, "summary": "1-4 | K", "doubles": 0, "triples": 0, "homeRuns": 0, "leftOnBase": 2, "plateAppearances": 4, "stolenBases": 0, "hitByPitch": 0, "groundOuts": 1, "flyOuts": 1, "airOuts": 1, "caughtStealing": 0, "groundIntoDoublePlay": 0, "totalBases": 1, "sacBunts": 0, "sacFlies": 0, "atBatsPerHomeRun": "-.--"},
+		"pitching": map[string]interface{}{},
+		"fielding": map[string]interface{}{"assists": 2, "putOuts": 1, "errors": 0, "chances": 3, "fielding": "1.000", "caughtStealing": 0, "passedBall": 0, "stolenBases": 0, "stolenBasePercentage": ".---", "pickoffs": 0},
+	}
+	boxPlayers := func(base int) map[string]interface{} {
+		players := map[string]interface{}{}
+		for i := 0; i < 26; i++ {
+			id := base + i
+			players[fmt.Sprintf("ID%d", id)] = map[string]interface{}{
+				"person": player(id), "jerseyNumber": fmt.Sprint(i + 1), "parentTeamId": 147, "battingOrder": fmt.Sprint((i%9 + 1) * 100),
+				"position": map[string]string{"code": "6", "name": "Shortstop", "type": "Infielder", "abbreviation": "SS"},
+				"status":   map[string]string{"code": "A", "description": "Active"}, "stats": stats, "seasonStats": stats,
+				"gameStatus":   map[string]bool{"isCurrentBatter": false, "isCurrentPitcher": false, "isOnBench": false, "isSubstitute": false},
+				"allPositions": []interface{}{map[string]string{"code": "6", "name": "Shortstop", "type": "Infielder", "abbreviation": "SS"}},
+			}
+		}
+		return players
+	}
+	people := map[string]interface{}{}
+	for _, base := range []int{600000, 610000} {
+		for i := 0; i < 26; i++ {
+			id := base + i
+			people[fmt.Sprintf("ID%d", id)] = map[string]interface{}{
+				"id": id, "fullName": fmt.Sprintf("Player %d", id), "link": fmt.Sprintf("/api/v1/people/%d", id), "firstName": "Player", "lastName": fmt.Sprint(id),
+				"primaryNumber": "7", "birthDate": "1995-04-26", "currentAge": 29, "birthCity": "Linden", "birthStateProvince": "CA", "birthCountry": "USA",
+				"height": "6' 7\"", "weight": 282, "active": true, "primaryPosition": map[string]string{"code": "9", "name": "Outfielder", "type": "Outfielder", "abbreviation": "RF"},
+				"useName": "Player", "boxscoreName": fmt.Sprintf("Player %d", id), "gender": "M", "isPlayer": true, "isVerified": true, "draftYear": 2013,
+				"mlbDebutDate": "2016-08-13", "batSide": map[string]string{"code": "R", "description": "Right"}, "pitchHand": map[string]string{"code": "R", "description": "Right"},
+				"nameFirstLast": fmt.Sprintf("Player %d", id), "nameSlug": fmt.Sprintf("player-%d", id), "strikeZoneTop": 3.41, "strikeZoneBottom": 1.62,
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"copyright": "Copyright 2024 MLB Advanced Media, L.P.",
+		"gamePk":    gamePk,
+		"link":      fmt.Sprintf("/api/v1.1/game/%d/feed/live", gamePk),
+		"metaData":  map[string]interface{}{"wait": 10, "timeStamp": timecode, "gameEvents": []string{"ball"}, "logicalEvents": []string{"countChange"}},
+		"gameData": map[string]interface{}{
+			"game":    map[string]interface{}{"pk": gamePk, "type": "R", "doubleHeader": "N", "id": "2024/06/01/nyamlb-bosmlb-1", "gamedayType": "P", "tiebreaker": "N", "gameNumber": 1, "calendarEventID": "14-745123-2024-06-01", "season": "2024", "seasonDisplay": "2024"},
+			"status":  map[string]interface{}{"abstractGameState": "Live", "codedGameState": "I", "detailedState": "In Progress", "statusCode": "I", "startTimeTBD": false, "abstractGameCode": "L"},
+			"players": people,
+		},
+		"liveData": map[string]interface{}{
+			"plays":     map[string]interface{}{"allPlays": plays, "scoringPlays": []int{12, 40}},
+			"linescore": map[string]interface{}{"currentInning": 9, "currentInningOrdinal": "9th", "inningState": "Bottom", "inningHalf": "Bottom", "isTopInning": false, "scheduledInnings": 9, "outs": 1, "balls": 1, "strikes": 2, "teams": map[string]interface{}{"away": map[string]int{"runs": 3}, "home": map[string]int{"runs": 2}}},
+			"boxscore":  map[string]interface{}{"teams": map[string]interface{}{"away": map[string]interface{}{"players": boxPlayers(600000)}, "home": map[string]interface{}{"players": boxPlayers(610000)}}},
+		},
+	}
+}
+
+// feedServer is a fake StatsAPI serving one game's live feed, its timestamps
+// and the json patches between them
+type feedServer struct {
+	mu         sync.Mutex
+	feed       []byte
+	patches    map[string][]byte // keyed by the startTimecode they apply from
+	requests   map[string]int    // keyed by endpoint: feed, timestamps or diffPatch
+	timestamps []string
+}
+
+func (s *feedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/feed/live"):
+		s.requests["feed"]++
+		w.Write(s.feed)
+	case strings.HasSuffix(r.URL.Path, "/timestamps"):
+		s.requests["timestamps"]++
+		json.NewEncoder(w).Encode(s.timestamps)
+	case strings.HasSuffix(r.URL.Path, "/diffPatch"):
+		s.requests["diffPatch"]++
+		patch, ok := s.patches[r.URL.Query().Get("startTimecode")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(patch)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// reset clears the requests counted so far
+func (s *feedServer) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = map[string]int{}
+}
+
+// newFeedServer serves the late game feed of gamePk at timecode
+func newFeedServer(t *testing.T, gamePk int64, timecode string) (*feedServer, *httptest.Server) {
+	feed, err := json.Marshal(lateGameFeed(t, gamePk, timecode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := &feedServer{feed: feed, patches: map[string][]byte{}, requests: map[string]int{}, timestamps: []string{timecode}}
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+	return fs, srv
+}
+
+func TestFeedTrackerUpdate(t *testing.T) {
+	const gamePk = 745123
+	ctx := context.Background()
+
+	t.Run("no change", func(t *testing.T) {
+		fs, srv := newFeedServer(t, gamePk, "20240601_231500")
+		c := newTestClient(srv)
+		c.Cache = nil
+		tracker := NewFeedTracker(c, gamePk)
+		if _, err := tracker.Update(ctx); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		fs.reset()
+
+		feed, err := tracker.Update(ctx)
+		if err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		if feed.MetaData.TimeStamp != "20240601_231500" || len(feed.LiveData.Plays.AllPlays) != 80 {
+			t.Errorf("feed at %s with %d plays", feed.MetaData.TimeStamp, len(feed.LiveData.Plays.AllPlays))
+		}
+		if want := map[string]int{"timestamps": 1}; fmt.Sprint(fs.requests) != fmt.Sprint(want) {
+			t.Errorf("requests = %v, want %v", fs.requests, want)
+		}
+	})
+
+	t.Run("patch chain", func(t *testing.T) {
+		fs, srv := newFeedServer(t, gamePk, "20240601_231500")
+		c := newTestClient(srv)
+		c.Cache = nil
+		tracker := NewFeedTracker(c, gamePk)
+		if _, err := tracker.Update(ctx); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		fs.reset()
+
+		fs.timestamps = append(fs.timestamps, "20240601_231520", "20240601_231540")
+		fs.patches["20240601_231500"] = []byte(`[
+			{"diff":[{"op":"replace","path":"/metaData/timeStamp","value":"20240601_231520"},{"op":"replace","path":"/liveData/linescore/outs","value":2}]},
+			{"diff":[{"op":"replace","path":"/metaData/timeStamp","value":"20240601_231540"},{"op":"test","path":"/liveData/linescore/outs","value":2},
+				{"op":"add","path":"/liveData/plays/allPlays/-","value":{"atBatIndex":80,"about":{"atBatIndex":80,"inning":9,"isComplete":false}}}]}
+		]`)
+		feed, err := tracker.Update(ctx)
+		if err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		if feed.MetaData.TimeStamp != "20240601_231540" || feed.LiveData.Linescore.Outs != 2 || len(feed.LiveData.Plays.AllPlays) != 81 {
+			t.Errorf("feed at %s with %d outs and %d plays, want both patches applied", feed.MetaData.TimeStamp, feed.LiveData.Linescore.Outs, len(feed.LiveData.Plays.AllPlays))
+		}
+		if want := map[string]int{"diffPatch": 1, "timestamps": 1}; fmt.Sprint(fs.requests) != fmt.Sprint(want) {
+			t.Errorf("requests = %v, want %v", fs.requests, want)
+		}
+		if got := tracker.Feed(); got.MetaData.TimeStamp != "20240601_231540" {
+			t.Errorf("Feed() at %s, want the patched feed", got.MetaData.TimeStamp)
+		}
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		fs, srv := newFeedServer(t, gamePk, "20240601_231500")
+		c := newTestClient(srv)
+		c.Cache = nil
+		tracker := NewFeedTracker(c, gamePk)
+		if _, err := tracker.Update(ctx); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		fs.reset()
+
+		// The patch expects a different feed than the one tracked
+		fs.timestamps = append(fs.timestamps, "20240601_231520")
+		fs.patches["20240601_231500"] = []byte(`[{"diff":[{"op":"test","path":"/liveData/linescore/outs","value":0},{"op":"replace","path":"/metaData/timeStamp","value":"20240601_231520"}]}]`)
+		full := lateGameFeed(t, gamePk, "20240601_231520")
+		full["liveData"].(map[string]interface{})["linescore"].(map[string]interface{})["outs"] = 2
+		fs.feed, _ = json.Marshal(full)
+
+		feed, err := tracker.Update(ctx)
+		if err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		if feed.MetaData.TimeStamp != "20240601_231520" || feed.LiveData.Linescore.Outs != 2 {
+			t.Errorf("feed at %s with %d outs, want the full feed fetched again", feed.MetaData.TimeStamp, feed.LiveData.Linescore.Outs)
+		}
+		if want := map[string]int{"diffPatch": 1, "feed": 1, "timestamps": 1}; fmt.Sprint(fs.requests) != fmt.Sprint(want) {
+			t.Errorf("requests = %v, want %v", fs.requests, want)
+		}
+
+		// Once StatsAPI no longer knows our timecode, the feed is fetched without asking for patches
+		fs.reset()
+		fs.timestamps = []string{"20240601_232000"}
+		if _, err := tracker.Update(ctx); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+		if want := map[string]int{"feed": 1, "timestamps": 1}; fmt.Sprint(fs.requests) != fmt.Sprint(want) {
+			t.Errorf("requests = %v, want %v", fs.requests, want)
+		}
+	})
+}
+
+// BenchmarkFeedTrackers measures the heap kept by a warm instance's trackers,
+// six late game feeds as in the function, and the peak heap while they update
+func BenchmarkFeedTrackers(b *testing.B) {
+	const trackers = 6
+	feeds := make(map[string][]byte, trackers)
+	for i := 0; i < trackers; i++ {
+		feed, err := json.Marshal(lateGameFeed(b, int64(745000+i), "20240601_231500"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		feeds[fmt.Sprint(745000+i)] = feed
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(feeds[strings.Split(r.URL.Path, "/")[4]])
+	}))
+	defer srv.Close()
+	c := NewClient()
+	c.BaseURL = srv.URL
+	c.Cache = nil
+	c.HTTPClient = srv.Client()
+	b.SetBytes(int64(len(feeds["745000"])))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var kept uint64
+	heap := startHeapSampler()
+	for i := 0; i < b.N; i++ {
+		ts := NewFeedTrackers(c, trackers)
+		for pk := 745000; pk < 745000+trackers; pk++ {
+			if _, err := ts.Tracker(int64(pk)).Update(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		}
+		kept = keptHeap(heap.baseline, ts)
+	}
+	reportPeakHeap(b, heap)
+	b.ReportMetric(float64(kept)/trackers, "kept-heap-B/tracker")
+}
+
+// keptHeap returns the heap in use above baseline after a GC, with v still reachable
+func keptHeap(baseline uint64, v interface{}) uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	runtime.KeepAlive(v)
+	if m.HeapInuse < baseline {
+		return 0
+	}
+	return m.HeapInuse - baseline
+}
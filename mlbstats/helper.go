@@ -1,14 +1,102 @@
 package mlbstats
 
-import "time"
-
-// statsAPIScheduleURL returns the URL for all the game schedule data for the given time
-func statsAPIScheduleURL(time time.Time) string {
-	host := "https://statsapi.mlb.com"
-	path := "/api/v1/schedule"
-	query := "?language=en&sportId=1&hydrate=game(content(summary,media(epg))),linescore(runners),flags,team,review&date="
-	month := time.Format("01")
-	day := time.Format("02")
-	year := time.Format("2006")
-	return host + path + query + month + "/" + day + "/" + year
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statsAPILiveFeedPath returns the path for the GUMBO live feed of the given game
+func statsAPILiveFeedPath(gamePk int64) string {
+	return "/api/v1.1/game/" + strconv.FormatInt(gamePk, 10) + "/feed/live"
+}
+
+// statsAPILiveFeedDiffPatchPath returns the path for the live feed json patches since the given timecode
+func statsAPILiveFeedDiffPatchPath(gamePk int64, startTimecode string) string {
+	return NewQuery(statsAPILiveFeedPath(gamePk)+"/diffPatch").Set("startTimecode", startTimecode).String()
+}
+
+// statsAPILiveFeedTimestampsPath returns the path for the timecodes of every live feed version
+func statsAPILiveFeedTimestampsPath(gamePk int64) string {
+	return statsAPILiveFeedPath(gamePk) + "/timestamps"
+}
+
+// statsAPIBoxscorePath returns the path for the boxscore of the given game
+func statsAPIBoxscorePath(gamePk int64) string {
+	return "/api/v1/game/" + strconv.FormatInt(gamePk, 10) + "/boxscore"
+}
+
+// statsAPIGameContentPath returns the path for the highlights, media and editorial content of the given game
+func statsAPIGameContentPath(gamePk int64) string {
+	return "/api/v1/game/" + strconv.FormatInt(gamePk, 10) + "/content"
+}
+
+// statsAPIStandingsPath returns the path for the regular season and wild card standings of the given leagues
+func statsAPIStandingsPath(date time.Time, leagueIDs []int64) string {
+	leagues := make([]string, len(leagueIDs))
+	for i, id := range leagueIDs {
+		leagues[i] = strconv.FormatInt(id, 10)
+	}
+	return NewQuery("/api/v1/standings").
+		Hydrate(Hydrate("team")).
+		Set("leagueId", strings.Join(leagues, ",")).
+		Set("season", strconv.Itoa(date.Year())).
+		Set("standingsTypes", string(StandingsTypeRegularSeason)+","+string(StandingsTypeWildCard)).
+		Date("date", date).
+		String()
+}
+
+// statsAPITeamsPath returns the path for every team of a sport in a season
+func statsAPITeamsPath(sportID SportID, season int) string {
+	return NewQuery("/api/v1/teams").
+		Sports(sportID).
+		Set("season", strconv.Itoa(season)).
+		String()
+}
+
+// statsAPIVenuesPath returns the path for every venue of a sport in a season
+func statsAPIVenuesPath(sportID SportID, season int) string {
+	return NewQuery("/api/v1/venues").
+		Hydrate(Hydrate("location"), Hydrate("timezone"), Hydrate("fieldInfo")).
+		Set("sportIds", strconv.FormatInt(int64(sportID), 10)).
+		Set("season", strconv.Itoa(season)).
+		String()
+}
+
+// statsAPIRosterPath returns the path for a team's active roster in a season
+func statsAPIRosterPath(teamID int64, season int) string {
+	return NewQuery("/api/v1/teams/"+strconv.FormatInt(teamID, 10)+"/roster").
+		Set("rosterType", "active").
+		Set("season", strconv.Itoa(season)).
+		String()
+}
+
+// statsAPIPeoplePath returns the path for the given people
+func statsAPIPeoplePath(personIDs []int64) string {
+	ids := make([]string, len(personIDs))
+	for i, id := range personIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	return NewQuery("/api/v1/people").Set("personIds", strings.Join(ids, ",")).String()
+}
+
+// statsAPIPlayByPlayPath returns the path for every play of the given game
+func statsAPIPlayByPlayPath(gamePk int64) string {
+	return "/api/v1/game/" + strconv.FormatInt(gamePk, 10) + "/playByPlay"
+}
+
+// winProbabilityFields are the fields of each win probability play Warning-Track
+// uses. The rest of every play is already in the live feed
+var winProbabilityFields = []string{
+	"atBatIndex", "about", "isComplete", "inning", "isTopInning",
+	"result", "description", "awayScore", "homeScore", "count", "outs",
+	"matchup", "postOnFirst", "postOnSecond", "postOnThird", "id",
+	"awayTeamWinProbability", "homeTeamWinProbability", "homeTeamWinProbabilityAdded", "leverageIndex",
+}
+
+// statsAPIWinProbabilityPath returns the path for the win probability after every play of the given game
+func statsAPIWinProbabilityPath(gamePk int64) string {
+	return NewQuery("/api/v1/game/" + strconv.FormatInt(gamePk, 10) + "/winProbability").
+		Fields(winProbabilityFields...).
+		String()
 }
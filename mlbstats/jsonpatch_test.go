@@ -0,0 +1,79 @@
+package mlbstats
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	const doc = `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":1}],"a~b/c":1}`
+
+	tests := []struct {
+		name string
+		ops  string
+		want string // empty when the patch must fail
+	}{
+		{"add member", `[{"op":"add","path":"/game/outs","value":2}]`, `{"game":{"status":"Live","inning":7,"outs":2},"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"add replaces member", `[{"op":"add","path":"/game/inning","value":8}]`, `{"game":{"status":"Live","inning":8},"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"add inserts into array", `[{"op":"add","path":"/plays/1","value":{"id":9}}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":9},{"id":1}],"a~b/c":1}`},
+		{"add at array end", `[{"op":"add","path":"/plays/2","value":{"id":2}}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":1},{"id":2}],"a~b/c":1}`},
+		{"add appends with -", `[{"op":"add","path":"/plays/-","value":{"id":2}}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":1},{"id":2}],"a~b/c":1}`},
+		{"add past array end", `[{"op":"add","path":"/plays/3","value":{}}]`, ""},
+		{"add under missing member", `[{"op":"add","path":"/missing/outs","value":2}]`, ""},
+		{"add whole document", `[{"op":"add","path":"","value":{"id":1}}]`, `{"id":1}`},
+		{"remove member", `[{"op":"remove","path":"/game/inning"}]`, `{"game":{"status":"Live"},"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"remove array element", `[{"op":"remove","path":"/plays/0"}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":1}],"a~b/c":1}`},
+		{"remove escaped member", `[{"op":"remove","path":"/a~0b~1c"}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":1}]}`},
+		{"remove missing member", `[{"op":"remove","path":"/game/outs"}]`, ""},
+		{"remove with -", `[{"op":"remove","path":"/plays/-"}]`, ""},
+		{"remove past array end", `[{"op":"remove","path":"/plays/2"}]`, ""},
+		{"replace member", `[{"op":"replace","path":"/game/status","value":"Final"}]`, `{"game":{"status":"Final","inning":7},"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"replace array element", `[{"op":"replace","path":"/plays/1/id","value":5}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":0},{"id":5}],"a~b/c":1}`},
+		{"replace missing member", `[{"op":"replace","path":"/game/outs","value":1}]`, ""},
+		{"replace leading zero index", `[{"op":"replace","path":"/plays/01","value":{}}]`, ""},
+		{"replace negative index", `[{"op":"replace","path":"/plays/-1","value":{}}]`, ""},
+		{"move member", `[{"op":"move","from":"/game/inning","path":"/inning"}]`, `{"game":{"status":"Live"},"inning":7,"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"move array element", `[{"op":"move","from":"/plays/0","path":"/plays/-"}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":1},{"id":0}],"a~b/c":1}`},
+		{"move missing member", `[{"op":"move","from":"/game/outs","path":"/outs"}]`, ""},
+		{"copy member", `[{"op":"copy","from":"/game","path":"/previous"},{"op":"replace","path":"/game/inning","value":8}]`, `{"game":{"status":"Live","inning":8},"previous":{"status":"Live","inning":7},"plays":[{"id":0},{"id":1}],"a~b/c":1}`},
+		{"copy into array", `[{"op":"copy","from":"/plays/1","path":"/plays/0"}]`, `{"game":{"status":"Live","inning":7},"plays":[{"id":1},{"id":0},{"id":1}],"a~b/c":1}`},
+		{"copy past array end", `[{"op":"copy","from":"/plays/2","path":"/last"}]`, ""},
+		{"test passes", `[{"op":"test","path":"/game","value":{"inning":7,"status":"Live"}},{"op":"test","path":"/plays/1/id","value":1}]`, doc},
+		{"test fails", `[{"op":"test","path":"/game/status","value":"Final"}]`, ""},
+		{"test missing member", `[{"op":"test","path":"/game/outs","value":0}]`, ""},
+		{"invalid pointer", `[{"op":"add","path":"game","value":1}]`, ""},
+		{"scalar parent", `[{"op":"add","path":"/game/status/code","value":"F"}]`, ""},
+		{"unknown operation", `[{"op":"merge","path":"/game","value":{}}]`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d interface{}
+			if err := json.Unmarshal([]byte(doc), &d); err != nil {
+				t.Fatal(err)
+			}
+			var ops []PatchOperation
+			if err := json.Unmarshal([]byte(tt.ops), &ops); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := applyPatch(d, ops)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("applyPatch = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyPatch() = %v", err)
+			}
+			var want interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applyPatch = %v\nwant %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package mlbstats
+
+// CodeDescription is StatsAPI's common code and description pair
+type CodeDescription struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// FeedMetaData describes the version of a LiveFeed
+type FeedMetaData struct {
+	GameEvents    []string `json:"gameEvents"`
+	LogicalEvents []string `json:"logicalEvents"`
+	TimeStamp     string   `json:"timeStamp"`
+	Wait          int64    `json:"wait"`
+}
+
+// GameData is the mostly static data about a game in a LiveFeed
+type GameData struct {
+	Datetime struct {
+		AMPM         string `json:"ampm"`
+		DateTime     string `json:"dateTime"`
+		DayNight     string `json:"dayNight"`
+		OfficialDate string `json:"officialDate"`
+		OriginalDate string `json:"originalDate"`
+		Time         string `json:"time"`
+	} `json:"datetime"`
+	Game struct {
+		CalendarEventID string `json:"calendarEventID"`
+		DoubleHeader    string `json:"doubleHeader"`
+		GameNumber      int64  `json:"gameNumber"`
+		GamedayType     string `json:"gamedayType"`
+		ID              string `json:"id"`
+		Pk              int64  `json:"pk"`
+		Season          string `json:"season"`
+		SeasonDisplay   string `json:"seasonDisplay"`
+		Tiebreaker      string `json:"tiebreaker"`
+		Type            string `json:"type"`
+	} `json:"game"`
+	ProbablePitchers struct {
+		Away Player `json:"away"`
+		Home Player `json:"home"`
+	} `json:"probablePitchers"`
+	Status Status `json:"status" statsapi:"required"`
+	Teams  struct {
+		Away Team `json:"away"`
+		Home Team `json:"home"`
+	} `json:"teams"`
+	Venue struct {
+		ID   int64  `json:"id"`
+		Link string `json:"link"`
+		Name string `json:"name"`
+	} `json:"venue"`
+}
+
+// HitData is the batted ball data of a pitch put in play
+type HitData struct {
+	Coordinates struct {
+		CoordX float64 `json:"coordX"`
+		CoordY float64 `json:"coordY"`
+	} `json:"coordinates"`
+	Hardness      string  `json:"hardness"`
+	LaunchAngle   float64 `json:"launchAngle"`
+	LaunchSpeed   float64 `json:"launchSpeed"`
+	Location      string  `json:"location"`
+	TotalDistance float64 `json:"totalDistance"`
+	Trajectory    string  `json:"trajectory"`
+}
+
+// LiveData is the changing state of a game in a LiveFeed
+type LiveData struct {
+	Boxscore  Boxscore `json:"boxscore"`
+	Decisions struct {
+		Loser  Player `json:"loser"`
+		Save   Player `json:"save"`
+		Winner Player `json:"winner"`
+	} `json:"decisions"`
+	Linescore Linescore `json:"linescore"`
+	Plays     Plays     `json:"plays"`
+}
+
+// LiveFeed is the format of the json returned from statsAPILiveFeedPath (GUMBO)
+type LiveFeed struct {
+	Copyright string       `json:"copyright"`
+	GameData  GameData     `json:"gameData"`
+	GamePk    int64        `json:"gamePk" statsapi:"required"`
+	Link      string       `json:"link"`
+	LiveData  LiveData     `json:"liveData"`
+	MetaData  FeedMetaData `json:"metaData"`
+}
+
+// Matchup is the batter and pitcher facing each other in a Play
+type Matchup struct {
+	BatSide      CodeDescription `json:"batSide"`
+	Batter       Player          `json:"batter"`
+	PitchHand    CodeDescription `json:"pitchHand"`
+	Pitcher      Player          `json:"pitcher"`
+	PostOnFirst  Player          `json:"postOnFirst"`
+	PostOnSecond Player          `json:"postOnSecond"`
+	PostOnThird  Player          `json:"postOnThird"`
+	Splits       struct {
+		Batter    string `json:"batter"`
+		MenOnBase string `json:"menOnBase"`
+		Pitcher   string `json:"pitcher"`
+	} `json:"splits"`
+}
+
+// PitchData is the tracking data of a single pitch
+type PitchData struct {
+	Breaks struct {
+		BreakAngle    float64 `json:"breakAngle"`
+		BreakLength   float64 `json:"breakLength"`
+		BreakY        float64 `json:"breakY"`
+		SpinDirection float64 `json:"spinDirection"`
+		SpinRate      float64 `json:"spinRate"`
+	} `json:"breaks"`
+	EndSpeed         float64 `json:"endSpeed"`
+	Extension        float64 `json:"extension"`
+	PlateTime        float64 `json:"plateTime"`
+	StartSpeed       float64 `json:"startSpeed"`
+	StrikeZoneBottom float64 `json:"strikeZoneBottom"`
+	StrikeZoneTop    float64 `json:"strikeZoneTop"`
+	TypeConfidence   float64 `json:"typeConfidence"`
+	Zone             int64   `json:"zone"`
+}
+
+// Play is a single plate appearance and everything that happened during it
+type Play struct {
+	About struct {
+		AtBatIndex       int64  `json:"atBatIndex"`
+		CaptivatingIndex int64  `json:"captivatingIndex"`
+		EndTime          string `json:"endTime"`
+		HalfInning       string `json:"halfInning"`
+		HasOut           bool   `json:"hasOut"`
+		HasReview        bool   `json:"hasReview"`
+		Inning           int64  `json:"inning"`
+		IsComplete       bool   `json:"isComplete"`
+		IsScoringPlay    bool   `json:"isScoringPlay"`
+		IsTopInning      bool   `json:"isTopInning"`
+		StartTime        string `json:"startTime"`
+	} `json:"about"`
+	ActionIndex []int64     `json:"actionIndex"`
+	AtBatIndex  int64       `json:"atBatIndex"`
+	Count       PlayCount   `json:"count"`
+	Matchup     Matchup     `json:"matchup"`
+	PitchIndex  []int64     `json:"pitchIndex"`
+	PlayEndTime string      `json:"playEndTime"`
+	PlayEvents  []PlayEvent `json:"playEvents"`
+	Result      struct {
+		AwayScore   int64  `json:"awayScore"`
+		Description string `json:"description"`
+		Event       string `json:"event"`
+		EventType   string `json:"eventType"`
+		HomeScore   int64  `json:"homeScore"`
+		IsOut       bool   `json:"isOut"`
+		Rbi         int64  `json:"rbi"`
+		Type        string `json:"type"`
+	} `json:"result"`
+	RunnerIndex []int64  `json:"runnerIndex"`
+	Runners     []Runner `json:"runners"`
+}
+
+// PlayCount is the balls, strikes and outs at a point in a Play
+type PlayCount struct {
+	Balls   int64 `json:"balls"`
+	Outs    int64 `json:"outs"`
+	Strikes int64 `json:"strikes"`
+}
+
+// PlayEvent is a pitch, pickoff or game action (e.g. a substitution) within a Play
+type PlayEvent struct {
+	Count   PlayCount `json:"count"`
+	Details struct {
+		AwayScore     int64           `json:"awayScore"`
+		BallColor     string          `json:"ballColor"`
+		Call          CodeDescription `json:"call"`
+		Code          string          `json:"code"`
+		Description   string          `json:"description"`
+		Event         string          `json:"event"`
+		EventType     string          `json:"eventType"`
+		HasReview     bool            `json:"hasReview"`
+		HomeScore     int64           `json:"homeScore"`
+		IsBall        bool            `json:"isBall"`
+		IsInPlay      bool            `json:"isInPlay"`
+		IsOut         bool            `json:"isOut"`
+		IsScoringPlay bool            `json:"isScoringPlay"`
+		IsStrike      bool            `json:"isStrike"`
+		Type          CodeDescription `json:"type"`
+	} `json:"details"`
+	EndTime        string     `json:"endTime"`
+	HitData        *HitData   `json:"hitData"`
+	Index          int64      `json:"index"`
+	IsPitch        bool       `json:"isPitch"`
+	IsSubstitution bool       `json:"isSubstitution"`
+	PitchData      *PitchData `json:"pitchData"`
+	PitchNumber    int64      `json:"pitchNumber"`
+	PlayID         string     `json:"playId"`
+	Player         Player     `json:"player"`
+	StartTime      string     `json:"startTime"`
+	Type           string     `json:"type"`
+}
+
+// PlayByPlay is the format of the json returned from the game playByPlay endpoint
+type PlayByPlay struct {
+	Copyright string `json:"copyright"`
+	Plays
+}
+
+// Plays are all the plays of a game
+type Plays struct {
+	AllPlays      []Play `json:"allPlays"`
+	CurrentPlay   Play   `json:"currentPlay"`
+	PlaysByInning []struct {
+		Bottom     []int64 `json:"bottom"`
+		EndIndex   int64   `json:"endIndex"`
+		StartIndex int64   `json:"startIndex"`
+		Top        []int64 `json:"top"`
+	} `json:"playsByInning"`
+	ScoringPlays []int64 `json:"scoringPlays"`
+}
+
+// Runner is the movement of a single runner during a Play
+type Runner struct {
+	Details struct {
+		Earned             bool   `json:"earned"`
+		Event              string `json:"event"`
+		EventType          string `json:"eventType"`
+		IsScoringEvent     bool   `json:"isScoringEvent"`
+		MovementReason     string `json:"movementReason"`
+		PlayIndex          int64  `json:"playIndex"`
+		Rbi                bool   `json:"rbi"`
+		ResponsiblePitcher Player `json:"responsiblePitcher"`
+		Runner             Player `json:"runner"`
+	} `json:"details"`
+	Movement struct {
+		End        string `json:"end"`
+		IsOut      bool   `json:"isOut"`
+		OriginBase string `json:"originBase"`
+		OutBase    string `json:"outBase"`
+		OutNumber  int64  `json:"outNumber"`
+		Start      string `json:"start"`
+	} `json:"movement"`
+}
+
+// WinProbabilityPlay is a Play with each team's win probability after it, as
+// returned from the game winProbability endpoint. Probabilities are percentages
+// and LeverageIndex is that of the situation the play started in
+type WinProbabilityPlay struct {
+	Play
+	AwayTeamWinProbability      float64  `json:"awayTeamWinProbability"`
+	ContextMetrics              struct{} `json:"contextMetrics"`
+	HomeTeamWinProbability      float64  `json:"homeTeamWinProbability"`
+	HomeTeamWinProbabilityAdded float64  `json:"homeTeamWinProbabilityAdded"`
+	LeverageIndex               float64  `json:"leverageIndex"`
+}
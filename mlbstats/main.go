@@ -1,32 +1,174 @@
 package mlbstats
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"time"
 )
 
-// GetSchedule returns a Schedule that contains all the requested day's games
-func GetSchedule(date time.Time) (Schedule, error) {
-	URL := statsAPIScheduleURL(date)
-	resp, err := http.Get(URL)
+// GetSchedule returns a Schedule that contains all the requested day's games for the given sport,
+// with any optional hydrations, e.g. GameConditionsHydrations, added to the defaults
+func (c *Client) GetSchedule(ctx context.Context, sportID SportID, date time.Time, hydrations ...Hydration) (Schedule, error) {
+	return c.Schedule(ctx, ScheduleQuery(sportID).Hydrate(hydrations...).Date("date", date))
+}
+
+// Schedule returns the Schedule for any schedule Query, e.g. one built from ScheduleQuery
+func (c *Client) Schedule(ctx context.Context, q Query) (Schedule, error) {
+	statsAPIScheduleResp := Schedule{}
+	err := c.get(ctx, q.String(), &statsAPIScheduleResp)
 	if err != nil {
-		return Schedule{}, fmt.Errorf("mlbStats#GetSchedule: Get %s, error: %s", URL, err)
+		return Schedule{}, fmt.Errorf("mlbStats#GetSchedule: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return statsAPIScheduleResp, nil
+}
+
+// GetScheduleRange returns the sport's games between start and end, inclusive, keyed
+// by their StatsAPI date (YYYY-MM-DD). Days without games are not included
+func (c *Client) GetScheduleRange(ctx context.Context, sportID SportID, start, end time.Time, hydrations ...Hydration) (map[string]DateData, error) {
+	statsAPIScheduleResp, err := c.Schedule(ctx, ScheduleQuery(sportID).Hydrate(hydrations...).Date("startDate", start).Date("endDate", end))
 	if err != nil {
-		return Schedule{}, fmt.Errorf("mlbStats#GetSchedule: reading Get response body error: %s", err)
+		return nil, fmt.Errorf("mlbStats#GetScheduleRange: %w", err)
 	}
 
-	statsAPIScheduleResp := Schedule{}
-	err = json.Unmarshal(body, &statsAPIScheduleResp)
+	return statsAPIScheduleResp.Days(), nil
+}
+
+// GetLiveFeed returns the full pitch-by-pitch LiveFeed of the given game
+func (c *Client) GetLiveFeed(ctx context.Context, gamePk int64) (LiveFeed, error) {
+	feed := LiveFeed{}
+	err := c.get(ctx, statsAPILiveFeedPath(gamePk), &feed)
 	if err != nil {
-		return Schedule{}, fmt.Errorf("mlbStats#GetSchedule: unmarshal error: %s", err)
+		return LiveFeed{}, fmt.Errorf("mlbStats#GetLiveFeed: %w", err)
 	}
 
-	return statsAPIScheduleResp, nil
+	return feed, nil
+}
+
+// GetLiveFeedTimestamps returns the timecodes of every version of the given game's LiveFeed
+func (c *Client) GetLiveFeedTimestamps(ctx context.Context, gamePk int64) ([]string, error) {
+	timestamps := []string{}
+	err := c.get(ctx, statsAPILiveFeedTimestampsPath(gamePk), &timestamps)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetLiveFeedTimestamps: %w", err)
+	}
+
+	return timestamps, nil
+}
+
+// GetBoxscore returns the boxscore of the given game: each team's totals, every
+// player's line, the batting order, pitchers used and the bullpen
+func (c *Client) GetBoxscore(ctx context.Context, gamePk int64) (Boxscore, error) {
+	box := Boxscore{}
+	err := c.get(ctx, statsAPIBoxscorePath(gamePk), &box)
+	if err != nil {
+		return Boxscore{}, fmt.Errorf("mlbStats#GetBoxscore: %w", err)
+	}
+
+	return box, nil
+}
+
+// GetGameContent returns the given game's content with its highlights. Highlights
+// are requested per game rather than hydrated on every schedule, as they are
+// only needed for live and just finished games
+func (c *Client) GetGameContent(ctx context.Context, gamePk int64) (Content, error) {
+	content := Content{}
+	err := c.get(ctx, statsAPIGameContentPath(gamePk), &content)
+	if err != nil {
+		return Content{}, fmt.Errorf("mlbStats#GetGameContent: %w", err)
+	}
+
+	return content, nil
+}
+
+// GetStandings returns the division and wild card standings of the given
+// leagues, e.g. LeagueAmerican and LeagueNational, as of date
+func (c *Client) GetStandings(ctx context.Context, date time.Time, leagueIDs ...int64) (Standings, error) {
+	standings := Standings{}
+	err := c.get(ctx, statsAPIStandingsPath(date, leagueIDs), &standings)
+	if err != nil {
+		return Standings{}, fmt.Errorf("mlbStats#GetStandings: %w", err)
+	}
+
+	return standings, nil
+}
+
+// GetTeams returns every team of the given sport in a season
+func (c *Client) GetTeams(ctx context.Context, sportID SportID, season int) ([]Team, error) {
+	resp := struct {
+		Copyright string `json:"copyright"`
+		Teams     []Team `json:"teams"`
+	}{}
+	err := c.get(ctx, statsAPITeamsPath(sportID, season), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetTeams: %w", err)
+	}
+
+	return resp.Teams, nil
+}
+
+// GetVenues returns every venue of the given sport in a season, with its location, time zone and field info
+func (c *Client) GetVenues(ctx context.Context, sportID SportID, season int) ([]Venue, error) {
+	resp := struct {
+		Copyright string  `json:"copyright"`
+		Venues    []Venue `json:"venues"`
+	}{}
+	err := c.get(ctx, statsAPIVenuesPath(sportID, season), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetVenues: %w", err)
+	}
+
+	return resp.Venues, nil
+}
+
+// GetRoster returns the players on a team's active roster in a season
+func (c *Client) GetRoster(ctx context.Context, teamID int64, season int) ([]RosterEntry, error) {
+	resp := struct {
+		Copyright string        `json:"copyright"`
+		Roster    []RosterEntry `json:"roster"`
+		TeamID    int64         `json:"teamId"`
+	}{}
+	err := c.get(ctx, statsAPIRosterPath(teamID, season), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetRoster: %w", err)
+	}
+
+	return resp.Roster, nil
+}
+
+// GetPeople returns the people with the given IDs
+func (c *Client) GetPeople(ctx context.Context, personIDs ...int64) ([]Person, error) {
+	resp := struct {
+		Copyright string   `json:"copyright"`
+		People    []Person `json:"people"`
+	}{}
+	err := c.get(ctx, statsAPIPeoplePath(personIDs), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetPeople: %w", err)
+	}
+
+	return resp.People, nil
+}
+
+// GetPlayByPlay returns every play of the given game, the same plays as a LiveFeed without the rest of the feed
+func (c *Client) GetPlayByPlay(ctx context.Context, gamePk int64) (PlayByPlay, error) {
+	plays := PlayByPlay{}
+	err := c.get(ctx, statsAPIPlayByPlayPath(gamePk), &plays)
+	if err != nil {
+		return PlayByPlay{}, fmt.Errorf("mlbStats#GetPlayByPlay: %w", err)
+	}
+
+	return plays, nil
+}
+
+// GetWinProbability returns every completed play of the given game with the
+// win probability after it and the win probability it added
+func (c *Client) GetWinProbability(ctx context.Context, gamePk int64) ([]WinProbabilityPlay, error) {
+	plays := []WinProbabilityPlay{}
+	err := c.get(ctx, statsAPIWinProbabilityPath(gamePk), &plays)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#GetWinProbability: %w", err)
+	}
+
+	return plays, nil
 }
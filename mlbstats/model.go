@@ -4,81 +4,20 @@ package mlbstats
 type Content struct {
 	Editorial  struct{} `json:"editorial"`
 	GameNotes  struct{} `json:"gameNotes"`
-	Highlights struct{} `json:"highlights"`
-	Link       string   `json:"link"`
-	Media      struct {
+	Highlights struct {
+		Highlights struct {
+			Items []MediaItem `json:"items"`
+		} `json:"highlights"`
+	} `json:"highlights"`
+	Link  string `json:"link"`
+	Media struct {
 		EnhancedGame bool `json:"enhancedGame"`
 		Epg          []struct {
-			Items []struct {
-				CallLetters      string `json:"callLetters"`
-				ContentID        string `json:"contentId"`
-				Description      string `json:"description"`
-				EspnAuthRequired bool   `json:"espnAuthRequired"`
-				FoxAuthRequired  bool   `json:"foxAuthRequired"`
-				FreeGame         bool   `json:"freeGame"`
-				Fs1AuthRequired  bool   `json:"fs1AuthRequired"`
-				ID               int64  `json:"id"`
-				Language         string `json:"language"`
-				MediaFeedSubType string `json:"mediaFeedSubType"`
-				MediaFeedType    string `json:"mediaFeedType"`
-				MediaID          string `json:"mediaId"`
-				MediaState       string `json:"mediaState"`
-				MlbnAuthRequired bool   `json:"mlbnAuthRequired"`
-				RenditionName    string `json:"renditionName"`
-				TbsAuthRequired  bool   `json:"tbsAuthRequired"`
-				Type             string `json:"type"`
-			} `json:"items"`
-			Title string `json:"title"`
+			Items []EpgItem `json:"items"`
+			Title string    `json:"title"`
 		} `json:"epg"`
-		EpgAlternate []struct {
-			Items []struct {
-				Blurb         string `json:"blurb"`
-				CclocationVtt string `json:"cclocationVtt"`
-				Date          string `json:"date"`
-				Description   string `json:"description"`
-				Duration      string `json:"duration"`
-				Headline      string `json:"headline"`
-				ID            string `json:"id"`
-				Image         struct {
-					AltText interface{} `json:"altText"`
-					Cuts    []struct {
-						AspectRatio string `json:"aspectRatio"`
-						At2x        string `json:"at2x"`
-						At3x        string `json:"at3x"`
-						Height      int64  `json:"height"`
-						Src         string `json:"src"`
-						Width       int64  `json:"width"`
-					} `json:"cuts"`
-					Title string `json:"title"`
-				} `json:"image"`
-				KeywordsAll []struct {
-					DisplayName string `json:"displayName"`
-					Type        string `json:"type"`
-					Value       string `json:"value"`
-				} `json:"keywordsAll"`
-				KeywordsDisplay []struct {
-					DisplayName string `json:"displayName"`
-					Type        string `json:"type"`
-					Value       string `json:"value"`
-				} `json:"keywordsDisplay"`
-				MediaPlaybackID  string `json:"mediaPlaybackId"`
-				MediaPlaybackURL string `json:"mediaPlaybackUrl"`
-				NoIndex          bool   `json:"noIndex"`
-				Playbacks        []struct {
-					Height string `json:"height"`
-					Name   string `json:"name"`
-					URL    string `json:"url"`
-					Width  string `json:"width"`
-				} `json:"playbacks"`
-				SeoTitle string `json:"seoTitle"`
-				Slug     string `json:"slug"`
-				State    string `json:"state"`
-				Title    string `json:"title"`
-				Type     string `json:"type"`
-			} `json:"items"`
-			Title string `json:"title"`
-		} `json:"epgAlternate"`
-		FreeGame bool `json:"freeGame"`
+		EpgAlternate []MediaGroup `json:"epgAlternate"`
+		FreeGame     bool         `json:"freeGame"`
 	} `json:"media"`
 	Summary struct {
 		HasHighlightsVideo bool `json:"hasHighlightsVideo"`
@@ -99,6 +38,27 @@ type DateData struct {
 	TotalItems           int64         `json:"totalItems"`
 }
 
+// EpgItem is a broadcast of a game in the electronic program guide, e.g. a MLB.TV feed
+type EpgItem struct {
+	CallLetters      string `json:"callLetters"`
+	ContentID        string `json:"contentId"`
+	Description      string `json:"description"`
+	EspnAuthRequired bool   `json:"espnAuthRequired"`
+	FoxAuthRequired  bool   `json:"foxAuthRequired"`
+	FreeGame         bool   `json:"freeGame"`
+	Fs1AuthRequired  bool   `json:"fs1AuthRequired"`
+	ID               int64  `json:"id"`
+	Language         string `json:"language"`
+	MediaFeedSubType string `json:"mediaFeedSubType"`
+	MediaFeedType    string `json:"mediaFeedType"`
+	MediaID          string `json:"mediaId"`
+	MediaState       string `json:"mediaState"`
+	MlbnAuthRequired bool   `json:"mlbnAuthRequired"`
+	RenditionName    string `json:"renditionName"`
+	TbsAuthRequired  bool   `json:"tbsAuthRequired"`
+	Type             string `json:"type"`
+}
+
 // Game is all the data for a mlbStats game
 type Game struct {
 	CalendarEventID string  `json:"calendarEventID"`
@@ -114,35 +74,54 @@ type Game struct {
 		NoHitter            bool `json:"noHitter"`
 		PerfectGame         bool `json:"perfectGame"`
 	} `json:"flags"`
-	GameDate               string    `json:"gameDate"`
-	GameNumber             int64     `json:"gameNumber"`
-	GamePk                 int64     `json:"gamePk"`
-	GameType               string    `json:"gameType"`
-	GamedayType            string    `json:"gamedayType"`
-	GamesInSeries          int64     `json:"gamesInSeries"`
-	IfNecessary            string    `json:"ifNecessary"`
-	IfNecessaryDescription string    `json:"ifNecessaryDescription"`
-	InningBreakLength      int64     `json:"inningBreakLength"`
-	Linescore              Linescore `json:"linescore"`
-	Link                   string    `json:"link"`
-	PublicFacing           bool      `json:"publicFacing"`
-	RecordSource           string    `json:"recordSource"`
-	ScheduledInnings       int64     `json:"scheduledInnings"`
-	Season                 string    `json:"season"`
-	SeasonDisplay          string    `json:"seasonDisplay"`
-	SeriesDescription      string    `json:"seriesDescription"`
-	SeriesGameNumber       int64     `json:"seriesGameNumber"`
-	Status                 Status    `json:"status"`
+	GameDate               string     `json:"gameDate" statsapi:"required"`
+	GameInfo               GameInfo   `json:"gameInfo"`
+	GameNumber             int64      `json:"gameNumber"`
+	GamePk                 int64      `json:"gamePk" statsapi:"required"`
+	GameType               string     `json:"gameType"`
+	GamedayType            string     `json:"gamedayType"`
+	GamesInSeries          int64      `json:"gamesInSeries"`
+	IfNecessary            string     `json:"ifNecessary"`
+	IfNecessaryDescription string     `json:"ifNecessaryDescription"`
+	InningBreakLength      int64      `json:"inningBreakLength"`
+	Linescore              Linescore  `json:"linescore"`
+	Link                   string     `json:"link"`
+	OfficialDate           string     `json:"officialDate"`
+	Officials              []Official `json:"officials"`
+	PublicFacing           bool       `json:"publicFacing"`
+	RecordSource           string     `json:"recordSource"`
+	ResumeDate             string     `json:"resumeDate"`
+	ResumedFromDate        string     `json:"resumedFromDate"`
+	ScheduledInnings       int64      `json:"scheduledInnings"`
+	Season                 string     `json:"season"`
+	SeasonDisplay          string     `json:"seasonDisplay"`
+	SeriesDescription      string     `json:"seriesDescription"`
+	SeriesGameNumber       int64      `json:"seriesGameNumber"`
+	Status                 Status     `json:"status" statsapi:"required"`
 	Teams                  struct {
 		Away TeamWithRecord `json:"away"`
 		Home TeamWithRecord `json:"home"`
 	} `json:"teams"`
 	Tiebreaker string `json:"tiebreaker"`
 	Venue      struct {
-		ID   int64  `json:"id"`
-		Link string `json:"link"`
-		Name string `json:"name"`
+		ID       int64  `json:"id"`
+		Link     string `json:"link"`
+		Name     string `json:"name"`
+		TimeZone struct {
+			ID     string `json:"id"`
+			Offset int64  `json:"offset"`
+			Tz     string `json:"tz"`
+		} `json:"timeZone"`
 	} `json:"venue"`
+	Weather Weather `json:"weather"`
+}
+
+// GameInfo is the attendance, first pitch time and duration of a game, from the gameInfo hydration
+type GameInfo struct {
+	Attendance           int64  `json:"attendance"`
+	DelayDurationMinutes int64  `json:"delayDurationMinutes"`
+	FirstPitch           string `json:"firstPitch"`
+	GameDurationMinutes  int64  `json:"gameDurationMinutes"`
 }
 
 // Linescore is the linescore data for a given game
@@ -151,9 +130,10 @@ type Linescore struct {
 	CurrentInning        int64  `json:"currentInning"`
 	CurrentInningOrdinal string `json:"currentInningOrdinal"`
 	Defense              struct {
-		Batter Player `json:"batter"`
-		InHole Player `json:"inHole"`
-		OnDeck Player `json:"onDeck"`
+		Batter  Player `json:"batter"`
+		InHole  Player `json:"inHole"`
+		OnDeck  Player `json:"onDeck"`
+		Pitcher Player `json:"pitcher"`
 	} `json:"defense"`
 	InningHalf  string `json:"inningHalf"`
 	InningState string `json:"inningState"`
@@ -175,6 +155,67 @@ type Linescore struct {
 	} `json:"teams"`
 }
 
+// Official is an umpire working a game, from the officials hydration
+type Official struct {
+	Official     Player `json:"official"`
+	OfficialType string `json:"officialType"`
+}
+
+// MediaGroup is a titled group of videos, e.g. the "Extended Highlights" of a game
+type MediaGroup struct {
+	Items []MediaItem `json:"items"`
+	Title string      `json:"title"`
+}
+
+// MediaImage is a video's thumbnail, cut to several sizes
+type MediaImage struct {
+	AltText interface{} `json:"altText"`
+	Cuts    []struct {
+		AspectRatio string `json:"aspectRatio"`
+		At2x        string `json:"at2x"`
+		At3x        string `json:"at3x"`
+		Height      int64  `json:"height"`
+		Src         string `json:"src"`
+		Width       int64  `json:"width"`
+	} `json:"cuts"`
+	Title string `json:"title"`
+}
+
+// MediaItem is a video clip, e.g. a highlight or a condensed game
+type MediaItem struct {
+	Blurb            string         `json:"blurb"`
+	CclocationVtt    string         `json:"cclocationVtt"`
+	Date             string         `json:"date"`
+	Description      string         `json:"description"`
+	Duration         string         `json:"duration"`
+	Headline         string         `json:"headline"`
+	ID               string         `json:"id"`
+	Image            MediaImage     `json:"image"`
+	KeywordsAll      []MediaKeyword `json:"keywordsAll"`
+	KeywordsDisplay  []MediaKeyword `json:"keywordsDisplay"`
+	MediaPlaybackID  string         `json:"mediaPlaybackId"`
+	MediaPlaybackURL string         `json:"mediaPlaybackUrl"`
+	NoIndex          bool           `json:"noIndex"`
+	Playbacks        []struct {
+		Height string `json:"height"`
+		Name   string `json:"name"`
+		URL    string `json:"url"`
+		Width  string `json:"width"`
+	} `json:"playbacks"`
+	SeoTitle string `json:"seoTitle"`
+	Slug     string `json:"slug"`
+	State    string `json:"state"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+}
+
+// MediaKeyword tags a MediaItem, e.g. with the players or team in it
+type MediaKeyword struct {
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+}
+
 // Offense shows what players are on base
 type Offense struct {
 	First  Player `json:"first"`
@@ -189,6 +230,27 @@ type Player struct {
 	Link     string `json:"link"`
 }
 
+// PersonStats is a block of a person's stats of one type and group, e.g. season pitching
+type PersonStats struct {
+	Group struct {
+		DisplayName string `json:"displayName"`
+	} `json:"group"`
+	Splits []struct{}    `json:"splits"`
+	Stats  PitchingStats `json:"stats"`
+	Type   struct {
+		DisplayName string `json:"displayName"`
+	} `json:"type"`
+}
+
+// ProbablePitcher is a team's announced starting pitcher, hydrated with season stats
+type ProbablePitcher struct {
+	FullName string        `json:"fullName"`
+	ID       int64         `json:"id"`
+	Link     string        `json:"link"`
+	Note     string        `json:"note"`
+	Stats    []PersonStats `json:"stats"`
+}
+
 // Schedule is the format of the json returned from statsAPIScheduleURL
 type Schedule struct {
 	Copyright            string     `json:"copyright"`
@@ -269,9 +331,17 @@ type TeamWithRecord struct {
 		Pct    string `json:"pct"`
 		Wins   int64  `json:"wins"`
 	} `json:"leagueRecord"`
-	Score        int64        `json:"score"`
-	SeriesNumber int64        `json:"seriesNumber"`
-	SplitSquad   bool         `json:"splitSquad"`
-	SpringLeague SpringLeague `json:"springLeague"`
-	Team         Team         `json:"team"`
+	ProbablePitcher ProbablePitcher `json:"probablePitcher"`
+	Score           int64           `json:"score"`
+	SeriesNumber    int64           `json:"seriesNumber"`
+	SplitSquad      bool            `json:"splitSquad"`
+	SpringLeague    SpringLeague    `json:"springLeague"`
+	Team            Team            `json:"team"`
+}
+
+// Weather is the conditions at a game's first pitch, from the weather hydration. Temp is in Fahrenheit
+type Weather struct {
+	Condition string `json:"condition"`
+	Temp      string `json:"temp"`
+	Wind      string `json:"wind"`
 }
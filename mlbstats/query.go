@@ -0,0 +1,213 @@
+package mlbstats
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GameType is a StatsAPI game type
+type GameType string
+
+// The StatsAPI game types
+const (
+	GameTypeAllStar            GameType = "A"
+	GameTypeDivisionSeries     GameType = "D"
+	GameTypeExhibition         GameType = "E"
+	GameTypeLeagueChampionship GameType = "L"
+	GameTypeRegularSeason      GameType = "R"
+	GameTypeSpringTraining     GameType = "S"
+	GameTypeWildCard           GameType = "F"
+	GameTypeWorldSeries        GameType = "W"
+)
+
+// Hydration asks StatsAPI to embed related data in a response. Hydrations nest,
+// e.g. game(content(summary)), and some take parameters, e.g. stats(group=[pitching])
+type Hydration struct {
+	Children []Hydration
+	Name     string
+	Params   []HydrationParam
+}
+
+// HydrationParam is a parameter of a Hydration
+type HydrationParam struct {
+	Key    string
+	Values []string
+}
+
+// Hydrate returns a Hydration with the given nested hydrations
+func Hydrate(name string, children ...Hydration) Hydration {
+	return Hydration{Children: children, Name: name}
+}
+
+// With returns a copy of h with the parameter key=[values...] added
+func (h Hydration) With(key string, values ...string) Hydration {
+	h.Params = append(h.Params[:len(h.Params):len(h.Params)], HydrationParam{Key: key, Values: values})
+	return h
+}
+
+// String returns the hydration as StatsAPI expects it in a hydrate= query
+func (h Hydration) String() string {
+	if len(h.Children) == 0 && len(h.Params) == 0 {
+		return h.Name
+	}
+	parts := make([]string, 0, len(h.Params)+len(h.Children))
+	for _, p := range h.Params {
+		parts = append(parts, p.Key+"=["+strings.Join(p.Values, ",")+"]")
+	}
+	for _, c := range h.Children {
+		parts = append(parts, c.String())
+	}
+	return h.Name + "(" + strings.Join(parts, ",") + ")"
+}
+
+// DefaultScheduleHydrations are the hydrations Warning-Track needs on every schedule
+var DefaultScheduleHydrations = []Hydration{
+	Hydrate("game", Hydrate("content", Hydrate("summary"), Hydrate("media", Hydrate("epg")))),
+	Hydrate("linescore", Hydrate("runners")),
+	Hydrate("flags"),
+	Hydrate("team"),
+	Hydrate("review"),
+	Hydrate("probablePitcher", Hydrate("stats").With("group", "pitching").With("type", "season")),
+	Hydrate("venue", Hydrate("timezone")),
+}
+
+// GameConditionsHydrations are the optional schedule hydrations for each game's
+// weather, attendance, first pitch and duration, and umpires
+var GameConditionsHydrations = []Hydration{
+	Hydrate("weather"),
+	Hydrate("gameInfo"),
+	Hydrate("officials"),
+}
+
+// Query builds the path and query string of a StatsAPI request. Every method
+// returns a modified copy, so a base Query can be shared and extended safely
+type Query struct {
+	fields     []string
+	gameTypes  []GameType
+	hydrations []Hydration
+	language   string
+	params     []queryParam
+	path       string
+	sportIDs   []SportID
+	teamIDs    []int64
+}
+
+// queryParam is an additional key=value pair of a Query
+type queryParam struct {
+	key   string
+	value string
+}
+
+// NewQuery returns a Query for the given StatsAPI path, e.g. /api/v1/schedule
+func NewQuery(path string) Query {
+	return Query{path: path}
+}
+
+// ScheduleQuery returns the Query for the given sport's schedule with DefaultScheduleHydrations
+func ScheduleQuery(sportID SportID) Query {
+	return NewQuery("/api/v1/schedule").
+		Language("en").
+		Sports(sportID).
+		Hydrate(DefaultScheduleHydrations...)
+}
+
+// Date returns a copy of q with the date parameter key set to t
+func (q Query) Date(key string, t time.Time) Query {
+	return q.Set(key, t.Format("01/02/2006"))
+}
+
+// Fields returns a copy of q that asks StatsAPI to only return the named fields.
+// Parent fields must be listed for their children to be returned
+func (q Query) Fields(fields ...string) Query {
+	q.fields = append(q.fields[:len(q.fields):len(q.fields)], fields...)
+	return q
+}
+
+// GameTypes returns a copy of q limited to the given game types
+func (q Query) GameTypes(gameTypes ...GameType) Query {
+	q.gameTypes = append(q.gameTypes[:len(q.gameTypes):len(q.gameTypes)], gameTypes...)
+	return q
+}
+
+// Hydrate returns a copy of q with the given hydrations added
+func (q Query) Hydrate(hydrations ...Hydration) Query {
+	q.hydrations = append(q.hydrations[:len(q.hydrations):len(q.hydrations)], hydrations...)
+	return q
+}
+
+// Language returns a copy of q with the response language set
+func (q Query) Language(language string) Query {
+	q.language = language
+	return q
+}
+
+// Set returns a copy of q with the additional parameter key=value
+func (q Query) Set(key, value string) Query {
+	q.params = append(q.params[:len(q.params):len(q.params)], queryParam{key: key, value: value})
+	return q
+}
+
+// Sports returns a copy of q limited to the given sports, replacing any set before
+func (q Query) Sports(sportIDs ...SportID) Query {
+	q.sportIDs = sportIDs
+	return q
+}
+
+// Teams returns a copy of q limited to the given team IDs
+func (q Query) Teams(teamIDs ...int64) Query {
+	q.teamIDs = append(q.teamIDs[:len(q.teamIDs):len(q.teamIDs)], teamIDs...)
+	return q
+}
+
+// String returns the path and query string of q
+func (q Query) String() string {
+	var params []string
+	add := func(key string, values ...string) {
+		if len(values) > 0 {
+			params = append(params, key+"="+queryEscape(strings.Join(values, ",")))
+		}
+	}
+
+	if q.language != "" {
+		add("language", q.language)
+	}
+	sportIDs := make([]string, len(q.sportIDs))
+	for i, id := range q.sportIDs {
+		sportIDs[i] = strconv.FormatInt(int64(id), 10)
+	}
+	add("sportId", sportIDs...)
+	gameTypes := make([]string, len(q.gameTypes))
+	for i, t := range q.gameTypes {
+		gameTypes[i] = string(t)
+	}
+	add("gameType", gameTypes...)
+	teamIDs := make([]string, len(q.teamIDs))
+	for i, id := range q.teamIDs {
+		teamIDs[i] = strconv.FormatInt(id, 10)
+	}
+	add("teamId", teamIDs...)
+	hydrations := make([]string, len(q.hydrations))
+	for i, h := range q.hydrations {
+		hydrations[i] = h.String()
+	}
+	add("hydrate", hydrations...)
+	add("fields", q.fields...)
+	for _, p := range q.params {
+		add(p.key, p.value)
+	}
+
+	if len(params) == 0 {
+		return q.path
+	}
+	return q.path + "?" + strings.Join(params, "&")
+}
+
+// queryUnescaper restores the characters StatsAPI expects verbatim in query values
+var queryUnescaper = strings.NewReplacer("%2C", ",", "%28", "(", "%29", ")", "%2F", "/", "%5B", "[", "%5D", "]", "%3D", "=")
+
+// queryEscape escapes a query value, keeping StatsAPI's hydration and date syntax readable
+func queryEscape(s string) string {
+	return queryUnescaper.Replace(url.QueryEscape(s))
+}
@@ -0,0 +1,133 @@
+package mlbstats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryString(t *testing.T) {
+	date := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.June, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "bare path",
+			got:  NewQuery("/api/v1/teams").String(),
+			want: "/api/v1/teams",
+		},
+		{
+			name: "schedule",
+			got:  ScheduleQuery(SportMLB).Date("date", date).String(),
+			want: "/api/v1/schedule?language=en&sportId=1&hydrate=" + scheduleHydrate + "&date=06/01/2024",
+		},
+		{
+			name: "schedule with game conditions",
+			got:  ScheduleQuery(SportMLB).Hydrate(GameConditionsHydrations...).Date("date", date).String(),
+			want: "/api/v1/schedule?language=en&sportId=1&hydrate=" + scheduleHydrate + ",weather,gameInfo,officials&date=06/01/2024",
+		},
+		{
+			name: "schedule range",
+			got:  ScheduleQuery(SportAAA).Date("startDate", date).Date("endDate", end).String(),
+			want: "/api/v1/schedule?language=en&sportId=11&hydrate=" + scheduleHydrate + "&startDate=06/01/2024&endDate=06/07/2024",
+		},
+		{
+			name: "sports replace",
+			got:  NewQuery("/api/v1/schedule").Sports(SportMLB).Sports(SportAA, SportHighA).String(),
+			want: "/api/v1/schedule?sportId=12,13",
+		},
+		{
+			name: "teams and game types",
+			got:  NewQuery("/api/v1/schedule").Teams(147).Teams(111).GameTypes(GameTypeRegularSeason, GameTypeWildCard).String(),
+			want: "/api/v1/schedule?gameType=R,F&teamId=147,111",
+		},
+		{
+			name: "fields",
+			got:  NewQuery("/api/v1/schedule").Fields("dates", "games", "gamePk").Language("es").String(),
+			want: "/api/v1/schedule?language=es&fields=dates,games,gamePk",
+		},
+		{
+			name: "hydration params",
+			got:  NewQuery("/api/v1/schedule").Hydrate(Hydrate("probablePitcher", Hydrate("stats").With("group", "pitching").With("type", "season"))).String(),
+			want: "/api/v1/schedule?hydrate=probablePitcher(stats(group=[pitching],type=[season]))",
+		},
+		{
+			name: "escaped values",
+			got:  NewQuery("/api/v1/people").Set("names", "a b&c").String(),
+			want: "/api/v1/people?names=a+b%26c",
+		},
+		{
+			name: "standings",
+			got:  statsAPIStandingsPath(date, []int64{LeagueAmerican, LeagueNational}),
+			want: "/api/v1/standings?hydrate=team&leagueId=103,104&season=2024&standingsTypes=regularSeason,wildCard&date=06/01/2024",
+		},
+		{
+			name: "live feed",
+			got:  statsAPILiveFeedPath(745123),
+			want: "/api/v1.1/game/745123/feed/live",
+		},
+		{
+			name: "live feed diff patch",
+			got:  statsAPILiveFeedDiffPatchPath(745123, "20240601_231500"),
+			want: "/api/v1.1/game/745123/feed/live/diffPatch?startTimecode=20240601_231500",
+		},
+		{
+			name: "live feed timestamps",
+			got:  statsAPILiveFeedTimestampsPath(745123),
+			want: "/api/v1.1/game/745123/feed/live/timestamps",
+		},
+		{
+			name: "teams",
+			got:  statsAPITeamsPath(SportMLB, 2024),
+			want: "/api/v1/teams?sportId=1&season=2024",
+		},
+		{
+			name: "venues",
+			got:  statsAPIVenuesPath(SportMLB, 2024),
+			want: "/api/v1/venues?hydrate=location,timezone,fieldInfo&sportIds=1&season=2024",
+		},
+		{
+			name: "win probability fields",
+			got:  statsAPIWinProbabilityPath(745123),
+			want: "/api/v1/game/745123/winProbability?fields=atBatIndex,about,isComplete,inning,isTopInning,result,description,awayScore,homeScore,count,outs,matchup,postOnFirst,postOnSecond,postOnThird,id,awayTeamWinProbability,homeTeamWinProbability,homeTeamWinProbabilityAdded,leverageIndex",
+		},
+		{
+			name: "game content",
+			got:  statsAPIGameContentPath(745123),
+			want: "/api/v1/game/745123/content",
+		},
+		{
+			name: "roster",
+			got:  statsAPIRosterPath(147, 2024),
+			want: "/api/v1/teams/147/roster?rosterType=active&season=2024",
+		},
+		{
+			name: "people",
+			got:  statsAPIPeoplePath([]int64{660271, 592450}),
+			want: "/api/v1/people?personIds=660271,592450",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got  %s\nwant %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// scheduleHydrate is DefaultScheduleHydrations as a hydrate= value
+const scheduleHydrate = "game(content(summary,media(epg))),linescore(runners),flags,team,review,probablePitcher(stats(group=[pitching],type=[season])),venue(timezone)"
+
+func TestQueryIsImmutable(t *testing.T) {
+	base := NewQuery("/api/v1/schedule").Teams(147)
+	a := base.Teams(111).String()
+	b := base.Teams(121).String()
+	if a != "/api/v1/schedule?teamId=147,111" || b != "/api/v1/schedule?teamId=147,121" {
+		t.Errorf("queries built from the same base share state: %s, %s", a, b)
+	}
+}
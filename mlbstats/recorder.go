@@ -0,0 +1,179 @@
+package mlbstats
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrFixtureNotFound is returned when replaying a request that was never recorded
+var ErrFixtureNotFound = errors.New("mlbStats: no recorded fixture for request")
+
+// RecordMode controls whether a Recorder captures or serves fixtures
+type RecordMode int
+
+// The Recorder modes
+const (
+	ModeRecord RecordMode = iota
+	ModeReplay
+)
+
+// fixtureHeaders are the only response headers kept in fixtures. Everything
+// else (dates, cookies, cache and CDN headers) changes between recordings
+var fixtureHeaders = []string{"Cache-Control", "Content-Type", "ETag", "Last-Modified", "Retry-After"}
+
+// Fixture is a recorded StatsAPI response
+type Fixture struct {
+	Body       string          `json:"body,omitempty"`
+	Header     http.Header     `json:"header"`
+	JSON       json.RawMessage `json:"json,omitempty"`
+	Method     string          `json:"method"`
+	StatusCode int             `json:"statusCode"`
+	URL        string          `json:"url"`
+}
+
+// Recorder is an http.RoundTripper that records StatsAPI responses to fixture
+// files in Dir, or replays them by URL so a Client can run offline. Plug it in
+// with Client.HTTPClient = &http.Client{Transport: recorder}
+type Recorder struct {
+	Dir       string
+	Mode      RecordMode
+	Transport http.RoundTripper
+}
+
+// NewRecorder returns a Recorder that records to or replays from dir
+func NewRecorder(dir string, mode RecordMode) *Recorder {
+	return &Recorder{Dir: dir, Mode: mode, Transport: http.DefaultTransport}
+}
+
+// RoundTrip records or replays a single request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := fixtureKey(req)
+	path := filepath.Join(r.Dir, fixtureFilename(key))
+
+	if r.Mode == ModeReplay {
+		return r.replay(req, key, path)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	f := Fixture{
+		Header:     normalizeHeader(resp.Header),
+		Method:     req.Method,
+		StatusCode: resp.StatusCode,
+		URL:        key,
+	}
+	if json.Valid(body) {
+		f.JSON = body
+	} else {
+		f.Body = string(body)
+	}
+	err = writeFixture(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#Recorder: %w", err)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// replay serves the fixture recorded for req
+func (r *Recorder) replay(req *http.Request, key, path string) (*http.Response, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s %s", ErrFixtureNotFound, req.Method, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#Recorder: %w", err)
+	}
+
+	f := Fixture{}
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, fmt.Errorf("mlbStats#Recorder: reading fixture %s: %w", path, err)
+	}
+
+	body := []byte(f.Body)
+	if len(f.JSON) > 0 {
+		body = f.JSON
+	}
+	return &http.Response{
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Header:        f.Header,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       req,
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+	}, nil
+}
+
+// fixtureKey identifies a request by method, path and sorted query, ignoring the host
+func fixtureKey(req *http.Request) string {
+	key := req.URL.EscapedPath()
+	if query := req.URL.Query(); len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	if req.Method != http.MethodGet {
+		key = req.Method + " " + key
+	}
+	return key
+}
+
+// fixtureFilename returns a readable, unique file name for a fixture key
+func fixtureFilename(key string) string {
+	sum := sha1.Sum([]byte(key))
+	path := key
+	if i := strings.IndexAny(path, "? "); i >= 0 {
+		path = path[:i]
+	}
+	name := strings.Trim(strings.NewReplacer("/", "-", ".", "_").Replace(path), "-")
+	return name + "-" + hex.EncodeToString(sum[:6]) + ".json"
+}
+
+// normalizeHeader keeps only the fixtureHeaders of h
+func normalizeHeader(h http.Header) http.Header {
+	normalized := http.Header{}
+	for _, name := range fixtureHeaders {
+		if values := h.Values(name); len(values) > 0 {
+			normalized[name] = values
+		}
+	}
+	return normalized
+}
+
+// writeFixture writes f to path, creating its directory if needed
+func writeFixture(path string, f Fixture) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,132 @@
+package mlbstats
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorderResponses are the responses of the test StatsAPI server by path
+var recorderResponses = map[string]string{
+	"/api/v1/schedule":                   `{"dates":[{"date":"2024-04-01","games":[{"gamePk":745001,"status":{"abstractGameCode":"F","codedGameState":"F","statusCode":"F"}},{"gamePk":745002,"status":{"abstractGameCode":"P","codedGameState":"S","statusCode":"S"}}]}]}`,
+	"/api/v1/standings":                  `{"records":[{"standingsType":"regularSeason"}]}`,
+	"/api/v1/teams":                      `{"teams":[{"id":147,"abbreviation":"NYY"}]}`,
+	"/api/v1/venues":                     `{"venues":[{"id":3313,"name":"Yankee Stadium"}]}`,
+	"/api/v1.1/game/745001/feed/live":    `{"gamePk":745001,"gameData":{"status":{"abstractGameCode":"F","codedGameState":"F","statusCode":"F"}}}`,
+	"/api/v1/game/745001/winProbability": `[{"atBatIndex":0,"homeTeamWinProbability":54.2}]`,
+	"/api/v1/game/745001/content":        `{"highlights":{"highlights":{"items":[{"id":"1","headline":"Judge homers"}]}}}`,
+}
+
+// recordedRequests makes the requests GetGameDataByDay makes for date and
+// returns every response
+func recordedRequests(ctx context.Context, c *Client, date time.Time) ([]interface{}, error) {
+	var responses []interface{}
+	schedule, err := c.GetSchedule(ctx, SportMLB, date, GameConditionsHydrations...)
+	if err != nil {
+		return nil, err
+	}
+	days, err := c.GetScheduleRange(ctx, SportMLB, date, date, GameConditionsHydrations...)
+	if err != nil {
+		return nil, err
+	}
+	standings, err := c.GetStandings(ctx, date, LeagueAmerican, LeagueNational)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := c.GetTeams(ctx, SportMLB, date.Year())
+	if err != nil {
+		return nil, err
+	}
+	venues, err := c.GetVenues(ctx, SportMLB, date.Year())
+	if err != nil {
+		return nil, err
+	}
+	responses = append(responses, schedule, days, standings, teams, venues)
+	for _, d := range schedule.Dates {
+		for _, g := range d.Games {
+			if !g.Status.IsLive() && !g.Status.IsFinal() {
+				continue
+			}
+			feed, err := c.GetLiveFeed(ctx, g.GamePk)
+			if err != nil {
+				return nil, err
+			}
+			wp, err := c.GetWinProbability(ctx, g.GamePk)
+			if err != nil {
+				return nil, err
+			}
+			content, err := c.GetGameContent(ctx, g.GamePk)
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, feed, wp, content)
+		}
+	}
+	return responses, nil
+}
+
+func TestRecorderReplaysRecordedRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := recorderResponses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		w.Header().Set("Set-Cookie", "session=1")
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	date := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	recording := NewClient()
+	recording.BaseURL = srv.URL
+	recording.HTTPClient = &http.Client{Transport: &Recorder{Dir: dir, Mode: ModeRecord, Transport: srv.Client().Transport}}
+	recorded, err := recordedRequests(context.Background(), recording, date)
+	if err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 8 {
+		t.Errorf("recorded %d fixtures, want 8", len(files))
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "Set-Cookie") {
+			t.Errorf("%s kept the Set-Cookie header", filepath.Base(f))
+		}
+	}
+
+	replaying := NewClient()
+	replaying.BaseURL = "https://statsapi.invalid"
+	replaying.HTTPClient = &http.Client{Transport: NewRecorder(dir, ModeReplay)}
+	replayed, err := recordedRequests(context.Background(), replaying, date)
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("replayed responses differ from the recorded ones\ngot:  %+v\nwant: %+v", replayed, recorded)
+	}
+
+	_, err = replaying.GetSchedule(context.Background(), SportMLB, date)
+	if !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("GetSchedule() without GameConditionsHydrations = %v, want ErrFixtureNotFound", err)
+	}
+}
@@ -8,7 +8,7 @@ import (
 // Date validates if a Schedule has a given date and returns a DateData object if it exists
 func (s Schedule) Date(date time.Time) (DateData, error) {
 	if s.Dates == nil || len(s.Dates) == 0 {
-		return DateData{}, fmt.Errorf("there are no dates in the schedule")
+		return DateData{}, &EmptyScheduleError{Date: date}
 	}
 
 	for _, d := range s.Dates {
@@ -26,7 +26,26 @@ func (s Schedule) Date(date time.Time) (DateData, error) {
 	return DateData{}, fmt.Errorf("unable to find a matching date from mlbstats: looking for %v. Received %v", date, s.Dates[0].Date)
 }
 
+// Days returns the schedule's DateData keyed by date (YYYY-MM-DD)
+func (s Schedule) Days() map[string]DateData {
+	days := make(map[string]DateData, len(s.Dates))
+	for _, d := range s.Dates {
+		days[d.Date] = d
+	}
+	return days
+}
+
 // InProgress returns a bool given a game's current state if the game is in progress or not
 func (s Status) InProgress() bool {
-	return (s.DetailedState == "In Progress" || s.DetailedState == "Manager Challenge")
+	return s.IsLive()
+}
+
+// SeasonPitching returns the probable pitcher's season pitching stats, if they were hydrated
+func (p ProbablePitcher) SeasonPitching() (PitchingStats, bool) {
+	for _, s := range p.Stats {
+		if s.Group.DisplayName == "pitching" && (s.Type.DisplayName == "season" || s.Type.DisplayName == "statsSingleSeason") {
+			return s.Stats, true
+		}
+	}
+	return PitchingStats{}, false
 }
@@ -0,0 +1,65 @@
+package mlbstats
+
+// Person is a player, coach or umpire
+type Person struct {
+	Active          bool            `json:"active"`
+	BatSide         CodeDescription `json:"batSide"`
+	BirthDate       string          `json:"birthDate"`
+	BoxscoreName    string          `json:"boxscoreName"`
+	CurrentAge      int64           `json:"currentAge"`
+	FirstLastName   string          `json:"firstLastName"`
+	FirstName       string          `json:"firstName"`
+	FullName        string          `json:"fullName"`
+	ID              int64           `json:"id"`
+	LastFirstName   string          `json:"lastFirstName"`
+	LastName        string          `json:"lastName"`
+	Link            string          `json:"link"`
+	NameSlug        string          `json:"nameSlug"`
+	PitchHand       CodeDescription `json:"pitchHand"`
+	PrimaryNumber   string          `json:"primaryNumber"`
+	PrimaryPosition Position        `json:"primaryPosition"`
+	UseName         string          `json:"useName"`
+}
+
+// RosterEntry is a player on a team's roster
+type RosterEntry struct {
+	JerseyNumber string          `json:"jerseyNumber"`
+	ParentTeamID int64           `json:"parentTeamId"`
+	Person       Player          `json:"person"`
+	Position     Position        `json:"position"`
+	Status       CodeDescription `json:"status"`
+}
+
+// Venue is a ballpark, hydrated with its location, time zone and field info
+type Venue struct {
+	Active    bool `json:"active"`
+	FieldInfo struct {
+		Capacity  int64  `json:"capacity"`
+		Center    int64  `json:"center"`
+		LeftLine  int64  `json:"leftLine"`
+		RightLine int64  `json:"rightLine"`
+		RoofType  string `json:"roofType"`
+		TurfType  string `json:"turfType"`
+	} `json:"fieldInfo"`
+	ID       int64  `json:"id"`
+	Link     string `json:"link"`
+	Location struct {
+		Address1           string `json:"address1"`
+		City               string `json:"city"`
+		Country            string `json:"country"`
+		DefaultCoordinates struct {
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+		} `json:"defaultCoordinates"`
+		PostalCode  string `json:"postalCode"`
+		State       string `json:"state"`
+		StateAbbrev string `json:"stateAbbrev"`
+	} `json:"location"`
+	Name     string `json:"name"`
+	Season   string `json:"season"`
+	TimeZone struct {
+		ID     string `json:"id"`
+		Offset int64  `json:"offset"`
+		Tz     string `json:"tz"`
+	} `json:"timeZone"`
+}
@@ -0,0 +1,48 @@
+package mlbstats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SportID is a StatsAPI sport, i.e. a level of play
+type SportID int64
+
+// The StatsAPI sports Warning-Track follows
+const (
+	SportMLB     SportID = 1
+	SportAAA     SportID = 11
+	SportAA      SportID = 12
+	SportHighA   SportID = 13
+	SportSingleA SportID = 14
+	SportWBC     SportID = 51
+)
+
+// sportSlugs are the names used for each SportID in requests and collection names
+var sportSlugs = map[SportID]string{
+	SportMLB:     "mlb",
+	SportAAA:     "aaa",
+	SportAA:      "aa",
+	SportHighA:   "high-a",
+	SportSingleA: "single-a",
+	SportWBC:     "wbc",
+}
+
+// ParseSportID returns the SportID for a slug (e.g. "aaa") or numeric StatsAPI sport ID
+func ParseSportID(s string) (SportID, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for id, slug := range sportSlugs {
+		if s == slug || s == fmt.Sprint(int64(id)) {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("mlbStats#ParseSportID: unsupported sport %q", s)
+}
+
+// String returns the sport's slug, e.g. "aaa"
+func (id SportID) String() string {
+	if slug, ok := sportSlugs[id]; ok {
+		return slug
+	}
+	return fmt.Sprintf("sport-%d", int64(id))
+}
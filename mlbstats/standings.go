@@ -0,0 +1,91 @@
+package mlbstats
+
+// The MLB league IDs
+const (
+	LeagueAmerican int64 = 103
+	LeagueNational int64 = 104
+)
+
+// StandingsType is a StatsAPI standings table
+type StandingsType string
+
+// The StatsAPI standings types
+const (
+	StandingsTypeDivisionLeaders StandingsType = "divisionLeaders"
+	StandingsTypeRegularSeason   StandingsType = "regularSeason"
+	StandingsTypeWildCard        StandingsType = "wildCard"
+)
+
+// IDLink is StatsAPI's common reference to another resource
+type IDLink struct {
+	ID   int64  `json:"id"`
+	Link string `json:"link"`
+}
+
+// Standings is the format of the json returned from the standings endpoint
+type Standings struct {
+	Copyright string            `json:"copyright"`
+	Records   []StandingsRecord `json:"records"`
+}
+
+// StandingsRecord is a single standings table, e.g. a division or a league's wild card
+type StandingsRecord struct {
+	Division      IDLink       `json:"division"`
+	LastUpdated   string       `json:"lastUpdated"`
+	League        IDLink       `json:"league"`
+	RoundRobin    struct{}     `json:"roundRobin"`
+	Sport         IDLink       `json:"sport"`
+	StandingsType string       `json:"standingsType"`
+	TeamRecords   []TeamRecord `json:"teamRecords"`
+}
+
+// TeamRecord is a team's place in a standings table. Games back, ranks and
+// elimination and magic numbers are strings as StatsAPI uses "-", "E" and "+1.0"
+type TeamRecord struct {
+	ClinchIndicator           string `json:"clinchIndicator"`
+	Clinched                  bool   `json:"clinched"`
+	ConferenceGamesBack       string `json:"conferenceGamesBack"`
+	DivisionChamp             bool   `json:"divisionChamp"`
+	DivisionGamesBack         string `json:"divisionGamesBack"`
+	DivisionLeader            bool   `json:"divisionLeader"`
+	DivisionRank              string `json:"divisionRank"`
+	EliminationNumber         string `json:"eliminationNumber"`
+	EliminationNumberDivision string `json:"eliminationNumberDivision"`
+	EliminationNumberLeague   string `json:"eliminationNumberLeague"`
+	EliminationNumberSport    string `json:"eliminationNumberSport"`
+	EliminationNumberWildCard string `json:"eliminationNumberWildCard"`
+	GamesBack                 string `json:"gamesBack"`
+	GamesPlayed               int64  `json:"gamesPlayed"`
+	HasWildcard               bool   `json:"hasWildcard"`
+	LastUpdated               string `json:"lastUpdated"`
+	LeagueGamesBack           string `json:"leagueGamesBack"`
+	LeagueRank                string `json:"leagueRank"`
+	LeagueRecord              struct {
+		Losses int64  `json:"losses"`
+		Pct    string `json:"pct"`
+		Ties   int64  `json:"ties"`
+		Wins   int64  `json:"wins"`
+	} `json:"leagueRecord"`
+	Losses                int64    `json:"losses"`
+	MagicNumber           string   `json:"magicNumber"`
+	Records               struct{} `json:"records"`
+	RunDifferential       int64    `json:"runDifferential"`
+	RunsAllowed           int64    `json:"runsAllowed"`
+	RunsScored            int64    `json:"runsScored"`
+	Season                string   `json:"season"`
+	SportGamesBack        string   `json:"sportGamesBack"`
+	SportRank             string   `json:"sportRank"`
+	SpringLeagueGamesBack string   `json:"springLeagueGamesBack"`
+	Streak                struct {
+		StreakCode   string `json:"streakCode"`
+		StreakNumber int64  `json:"streakNumber"`
+		StreakType   string `json:"streakType"`
+	} `json:"streak"`
+	Team                      Team   `json:"team"`
+	WildCardEliminationNumber string `json:"wildCardEliminationNumber"`
+	WildCardGamesBack         string `json:"wildCardGamesBack"`
+	WildCardLeader            bool   `json:"wildCardLeader"`
+	WildCardRank              string `json:"wildCardRank"`
+	WinningPercentage         string `json:"winningPercentage"`
+	Wins                      int64  `json:"wins"`
+}
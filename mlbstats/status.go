@@ -0,0 +1,259 @@
+package mlbstats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AbstractGameCode is the coarsest game state: preview, live or final
+type AbstractGameCode string
+
+// The StatsAPI abstract game codes
+const (
+	AbstractGameCodeFinal   AbstractGameCode = "F"
+	AbstractGameCodeLive    AbstractGameCode = "L"
+	AbstractGameCodePreview AbstractGameCode = "P"
+)
+
+// CodedGameState is StatsAPI's single letter game state
+type CodedGameState string
+
+// The StatsAPI coded game states
+const (
+	CodedGameStateCancelled        CodedGameState = "C"
+	CodedGameStateFinal            CodedGameState = "F"
+	CodedGameStateForfeit          CodedGameState = "Q"
+	CodedGameStateGameOver         CodedGameState = "O"
+	CodedGameStateInProgress       CodedGameState = "I"
+	CodedGameStateManagerChallenge CodedGameState = "M"
+	CodedGameStatePostponed        CodedGameState = "D"
+	CodedGameStatePreGame          CodedGameState = "P"
+	CodedGameStateScheduled        CodedGameState = "S"
+	CodedGameStateSuspended        CodedGameState = "U"
+	CodedGameStateSuspendedLive    CodedGameState = "T"
+	CodedGameStateUmpireReview     CodedGameState = "N"
+)
+
+// StatusCode is StatsAPI's detailed status code. The first letter usually
+// matches the CodedGameState and the second gives the reason, e.g. IR is
+// "Delayed: Rain" and DR is "Postponed: Rain"
+type StatusCode string
+
+// The StatsAPI status codes with special meaning
+const (
+	StatusCodeInProgress StatusCode = "I"
+	StatusCodePreGame    StatusCode = "P"
+	StatusCodeWarmup     StatusCode = "PW"
+)
+
+// GamePhase is a game's state as Warning-Track reasons about it, derived from
+// a Status's AbstractGameCode, CodedGameState and StatusCode
+type GamePhase int
+
+// The GamePhases
+const (
+	PhaseUnknown GamePhase = iota
+	PhaseScheduled
+	PhasePreGame
+	PhaseWarmup
+	PhaseDelayedStart
+	PhaseLive
+	PhaseReview
+	PhaseDelayed
+	PhaseSuspended
+	PhaseGameOver
+	PhaseFinal
+	PhasePostponed
+	PhaseCancelled
+	PhaseForfeit
+)
+
+// phaseNames are the names of each GamePhase
+var phaseNames = map[GamePhase]string{
+	PhaseUnknown:      "unknown",
+	PhaseScheduled:    "scheduled",
+	PhasePreGame:      "pre-game",
+	PhaseWarmup:       "warmup",
+	PhaseDelayedStart: "delayed-start",
+	PhaseLive:         "live",
+	PhaseReview:       "review",
+	PhaseDelayed:      "delayed",
+	PhaseSuspended:    "suspended",
+	PhaseGameOver:     "game-over",
+	PhaseFinal:        "final",
+	PhasePostponed:    "postponed",
+	PhaseCancelled:    "cancelled",
+	PhaseForfeit:      "forfeit",
+}
+
+func (p GamePhase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return phaseNames[PhaseUnknown]
+}
+
+// ParseGamePhase returns the GamePhase with the given name, e.g. "live"
+func ParseGamePhase(name string) GamePhase {
+	for p, n := range phaseNames {
+		if n == name {
+			return p
+		}
+	}
+	return PhaseUnknown
+}
+
+// Phase returns the status's GamePhase
+func (s Status) Phase() GamePhase {
+	code := StatusCode(s.StatusCode)
+
+	switch CodedGameState(s.CodedGameState) {
+	case CodedGameStateScheduled:
+		return PhaseScheduled
+	case CodedGameStatePreGame:
+		switch {
+		case code == StatusCodeWarmup:
+			return PhaseWarmup
+		case code == StatusCodePreGame || code == "":
+			return PhasePreGame
+		}
+		return PhaseDelayedStart
+	case CodedGameStateInProgress:
+		if code == StatusCodeInProgress || code == "" {
+			return PhaseLive
+		}
+		return PhaseDelayed
+	case CodedGameStateManagerChallenge, CodedGameStateUmpireReview:
+		return PhaseReview
+	case CodedGameStateSuspended, CodedGameStateSuspendedLive:
+		return PhaseSuspended
+	case CodedGameStateGameOver:
+		return PhaseGameOver
+	case CodedGameStateFinal:
+		return PhaseFinal
+	case CodedGameStatePostponed:
+		return PhasePostponed
+	case CodedGameStateCancelled:
+		return PhaseCancelled
+	case CodedGameStateForfeit:
+		return PhaseForfeit
+	}
+
+	// Fall back to the detailed state for codes we have not seen
+	detailed := strings.ToLower(s.DetailedState)
+	switch {
+	case strings.HasPrefix(detailed, "delayed start"):
+		return PhaseDelayedStart
+	case strings.HasPrefix(detailed, "delayed"):
+		return PhaseDelayed
+	case strings.HasPrefix(detailed, "suspended"):
+		return PhaseSuspended
+	case strings.HasPrefix(detailed, "postponed"):
+		return PhasePostponed
+	case strings.HasPrefix(detailed, "cancelled"):
+		return PhaseCancelled
+	case strings.Contains(detailed, "challenge"), strings.Contains(detailed, "review"):
+		return PhaseReview
+	}
+	switch AbstractGameCode(s.AbstractGameCode) {
+	case AbstractGameCodePreview:
+		return PhaseScheduled
+	case AbstractGameCodeLive:
+		return PhaseLive
+	case AbstractGameCodeFinal:
+		return PhaseFinal
+	}
+	return PhaseUnknown
+}
+
+// IsDelayed reports if the game's start or play is delayed
+func (s Status) IsDelayed() bool {
+	p := s.Phase()
+	return p == PhaseDelayed || p == PhaseDelayedStart
+}
+
+// IsFinal reports if the game has been completed
+func (s Status) IsFinal() bool {
+	p := s.Phase()
+	return p == PhaseGameOver || p == PhaseFinal || p == PhaseForfeit
+}
+
+// IsLive reports if the game has started and not yet ended or been suspended.
+// Reviews and in-game delays are live
+func (s Status) IsLive() bool {
+	p := s.Phase()
+	return p == PhaseLive || p == PhaseReview || p == PhaseDelayed
+}
+
+// IsPostponed reports if the game has been postponed to another day
+func (s Status) IsPostponed() bool {
+	return s.Phase() == PhasePostponed
+}
+
+// IsSuspended reports if the game has been suspended, to be resumed later
+func (s Status) IsSuspended() bool {
+	return s.Phase() == PhaseSuspended
+}
+
+// phaseTransitions lists the phases a game may move to from each phase. Staying
+// in the same phase is always valid and PhaseUnknown is never flagged
+var phaseTransitions = map[GamePhase][]GamePhase{
+	PhaseScheduled:    {PhasePreGame, PhaseWarmup, PhaseDelayedStart, PhaseLive, PhasePostponed, PhaseCancelled, PhaseForfeit},
+	PhasePreGame:      {PhaseScheduled, PhaseWarmup, PhaseDelayedStart, PhaseLive, PhasePostponed, PhaseCancelled, PhaseForfeit},
+	PhaseWarmup:       {PhasePreGame, PhaseDelayedStart, PhaseLive, PhasePostponed, PhaseCancelled},
+	PhaseDelayedStart: {PhasePreGame, PhaseWarmup, PhaseLive, PhaseSuspended, PhasePostponed, PhaseCancelled},
+	PhaseLive:         {PhaseReview, PhaseDelayed, PhaseSuspended, PhaseGameOver, PhaseFinal, PhaseCancelled, PhaseForfeit},
+	PhaseReview:       {PhaseLive, PhaseDelayed, PhaseGameOver, PhaseFinal},
+	PhaseDelayed:      {PhaseLive, PhaseReview, PhaseSuspended, PhaseGameOver, PhaseFinal, PhaseCancelled},
+	PhaseSuspended:    {PhaseScheduled, PhasePreGame, PhaseWarmup, PhaseDelayedStart, PhaseLive, PhaseGameOver, PhaseFinal, PhaseCancelled},
+	PhaseGameOver:     {PhaseReview, PhaseFinal},
+	PhaseFinal:        {},
+	PhasePostponed:    {PhaseScheduled, PhasePreGame, PhaseWarmup, PhaseDelayedStart, PhaseLive, PhaseCancelled},
+	PhaseCancelled:    {},
+	PhaseForfeit:      {},
+}
+
+// TransitionError is returned for a game moving between phases that should never follow each other
+type TransitionError struct {
+	From GamePhase
+	To   GamePhase
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("impossible game status transition from %s to %s", e.From, e.To)
+}
+
+// reachablePhases lists every phase a game may reach from each phase through
+// any number of phaseTransitions
+var reachablePhases = func() map[GamePhase]map[GamePhase]bool {
+	reachable := make(map[GamePhase]map[GamePhase]bool, len(phaseTransitions))
+	for from := range phaseTransitions {
+		seen := map[GamePhase]bool{}
+		queue := []GamePhase{from}
+		for len(queue) > 0 {
+			p := queue[0]
+			queue = queue[1:]
+			for _, next := range phaseTransitions[p] {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		reachable[from] = seen
+	}
+	return reachable
+}()
+
+// ValidateTransition returns a *TransitionError if a game can never reach phase
+// to from phase from. Snapshots are minutes apart, so intermediate phases are
+// often missed and only regressions out of a finished game are flagged
+func ValidateTransition(from, to GamePhase) error {
+	if from == to || from == PhaseUnknown || to == PhaseUnknown {
+		return nil
+	}
+	if reachablePhases[from][to] {
+		return nil
+	}
+	return &TransitionError{From: from, To: to}
+}
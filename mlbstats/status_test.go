@@ -0,0 +1,48 @@
+package mlbstats
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from  GamePhase
+		to    GamePhase
+		valid bool
+	}{
+		{PhaseScheduled, PhaseScheduled, true},
+		{PhaseScheduled, PhaseLive, true},
+		{PhaseScheduled, PhaseFinal, true},
+		{PhasePreGame, PhaseGameOver, true},
+		{PhaseWarmup, PhaseFinal, true},
+		{PhaseLive, PhaseFinal, true},
+		{PhaseReview, PhaseSuspended, true},
+		{PhaseSuspended, PhaseFinal, true},
+		{PhasePostponed, PhaseFinal, true},
+		{PhaseGameOver, PhaseFinal, true},
+		{PhaseUnknown, PhaseLive, true},
+		{PhaseFinal, PhaseUnknown, true},
+		{PhaseFinal, PhaseLive, false},
+		{PhaseFinal, PhaseScheduled, false},
+		{PhaseGameOver, PhaseLive, true},
+		{PhaseFinal, PhaseReview, false},
+		{PhaseCancelled, PhaseLive, false},
+		{PhaseForfeit, PhaseFinal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			err := ValidateTransition(tt.from, tt.to)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("ValidateTransition() = %v, want nil", err)
+				}
+				return
+			}
+			var terr *TransitionError
+			if !errors.As(err, &terr) {
+				t.Fatalf("ValidateTransition() = %v, want *TransitionError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package mlbstats
+
+import (
+	"io"
+	"strings"
+)
+
+// DefaultMaxResponseSize is the largest StatsAPI response body NewClient will read
+const DefaultMaxResponseSize = 32 << 20
+
+// DefaultSkipFields are the json keys NewClient drops from responses before
+// decoding, all unused by Warning-Track. The images of epgAlternate videos,
+// cut to a dozen sizes, are most of a hydrated schedule, keywordsAll tags
+// every video with dozens of keywords and at2x and at3x are the retina URLs
+// of every image cut
+var DefaultSkipFields = []string{"at2x", "at3x", "epgAlternate.image", "keywordsAll"}
+
+// maxSkipKeyLen bounds how much of each json string is kept to match skip keys
+const maxSkipKeyLen = 64
+
+// limitedBody reads a response body, failing once more than limit bytes are read.
+// The first read error is kept so it can be told apart from a json error
+type limitedBody struct {
+	err   error
+	limit int64
+	n     int64
+	r     io.Reader
+	URL   string
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+	if b.limit > 0 && b.n > b.limit {
+		b.err = &ResponseTooLargeError{Limit: b.limit, URL: b.URL}
+		return 0, b.err
+	}
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// skipState is where a skipReader is in the json stream
+type skipState int
+
+const (
+	skipStateNormal skipState = iota
+	skipStateString
+	skipStateAfterString
+	skipStateValueStart
+	skipStateValue
+	skipStateScalar
+)
+
+// skipReader streams json, replacing the value of every object key in skip
+// with null, at any depth, so the skipped subtrees are never buffered or
+// decoded. A key written parent.key is only skipped inside the value of
+// parent. Keys are matched as they appear on the wire, without unescaping
+type skipReader struct {
+	depth        int
+	escaped      bool
+	inString     bool
+	key          []byte
+	keyLen       int
+	level        int
+	out          []byte
+	pendingScope string
+	r            io.Reader
+	scopes       []skipScope
+	scoped       map[string]map[string]bool
+	scratch      []byte
+	skip         map[string]bool
+	state        skipState
+}
+
+// skipScope is the value of a parent key whose scoped keys are being skipped
+type skipScope struct {
+	level  int
+	parent string
+}
+
+// newSkipReader returns a reader of r with the values of the given keys replaced by null
+func newSkipReader(r io.Reader, keys []string) *skipReader {
+	skip := make(map[string]bool, len(keys))
+	scoped := map[string]map[string]bool{}
+	for _, k := range keys {
+		parent, key, ok := strings.Cut(k, ".")
+		if !ok {
+			skip[k] = true
+			continue
+		}
+		if scoped[parent] == nil {
+			scoped[parent] = map[string]bool{}
+		}
+		scoped[parent][key] = true
+	}
+	return &skipReader{
+		key:     make([]byte, 0, maxSkipKeyLen),
+		r:       r,
+		scoped:  scoped,
+		scratch: make([]byte, 32<<10),
+		skip:    skip,
+	}
+}
+
+// skips reports if the value of key is skipped where the stream is
+func (s *skipReader) skips(key []byte) bool {
+	if s.skip[string(key)] {
+		return true
+	}
+	for _, scope := range s.scopes {
+		if s.scoped[scope.parent][string(key)] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *skipReader) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		n, err := s.r.Read(s.scratch)
+		for _, c := range s.scratch[:n] {
+			s.process(c)
+		}
+		if err != nil && len(s.out) == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+// process consumes a single byte of the json stream
+func (s *skipReader) process(c byte) {
+	switch s.state {
+	case skipStateNormal:
+		s.out = append(s.out, c)
+		switch {
+		case c == '"':
+			s.state = skipStateString
+			s.escaped = false
+			s.key = s.key[:0]
+			s.keyLen = 0
+		case c == '{' || c == '[':
+			s.level++
+			if s.pendingScope != "" {
+				s.scopes = append(s.scopes, skipScope{level: s.level, parent: s.pendingScope})
+			}
+		case c == '}' || c == ']':
+			s.level--
+			for len(s.scopes) > 0 && s.scopes[len(s.scopes)-1].level > s.level {
+				s.scopes = s.scopes[:len(s.scopes)-1]
+			}
+		}
+		if !isJSONSpace(c) {
+			s.pendingScope = ""
+		}
+	case skipStateString:
+		s.out = append(s.out, c)
+		switch {
+		case s.escaped:
+			s.escaped = false
+		case c == '\\':
+			s.escaped = true
+		case c == '"':
+			s.state = skipStateAfterString
+			return
+		}
+		s.keyLen++
+		if len(s.key) < maxSkipKeyLen {
+			s.key = append(s.key, c)
+		}
+	case skipStateAfterString:
+		switch {
+		case isJSONSpace(c):
+			s.out = append(s.out, c)
+		case c == ':':
+			s.out = append(s.out, c)
+			s.state = skipStateNormal
+			if s.keyLen > maxSkipKeyLen {
+				return
+			}
+			switch {
+			case s.skips(s.key):
+				s.state = skipStateValueStart
+			case s.scoped[string(s.key)] != nil:
+				s.pendingScope = string(s.key)
+			}
+		default:
+			s.state = skipStateNormal
+			s.process(c)
+		}
+	case skipStateValueStart:
+		if isJSONSpace(c) {
+			return
+		}
+		s.out = append(s.out, "null"...)
+		s.depth = 0
+		s.escaped = false
+		s.inString = false
+		s.state = skipStateValue
+		if c != '{' && c != '[' && c != '"' {
+			s.state = skipStateScalar
+			return
+		}
+		s.process(c)
+	case skipStateValue:
+		switch {
+		case s.inString && s.escaped:
+			s.escaped = false
+		case s.inString && c == '\\':
+			s.escaped = true
+		case c == '"':
+			s.inString = !s.inString
+		case s.inString:
+		case c == '{' || c == '[':
+			s.depth++
+		case c == '}' || c == ']':
+			s.depth--
+		}
+		if s.depth == 0 && !s.inString {
+			s.state = skipStateNormal
+		}
+	case skipStateScalar:
+		if isJSONSpace(c) || c == ',' || c == '}' || c == ']' {
+			s.state = skipStateNormal
+			s.process(c)
+		}
+	}
+}
+
+// isJSONSpace reports if c is json whitespace
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
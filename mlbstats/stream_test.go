@@ -0,0 +1,267 @@
+package mlbstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSkipReader(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		keys []string
+		want string
+	}{
+		{
+			name: "no keys",
+			in:   `{"a":1,"b":[true,"x"]}`,
+			want: `{"a":1,"b":[true,"x"]}`,
+		},
+		{
+			name: "values of every type at any depth",
+			in:   `{"k":{"x":1},"a":{"k":[1,{"k":"s"}],"b":2},"c":[{"k":true}],"d":{"k":null}}`,
+			keys: []string{"k"},
+			want: `{"k":null,"a":{"k":null,"b":2},"c":[{"k":null}],"d":{"k":null}}`,
+		},
+		{
+			name: "strings with escapes and brackets",
+			in:   `{"k":"a\"}]b","k2":"{\"k\":1}","n":1}`,
+			keys: []string{"k"},
+			want: `{"k":null,"k2":"{\"k\":1}","n":1}`,
+		},
+		{
+			name: "whitespace",
+			in:   "{ \"k\" : [ 1 , 2 ] , \"n\" : 1 }",
+			keys: []string{"k"},
+			want: "{ \"k\" :null , \"n\" : 1 }",
+		},
+		{
+			name: "scoped keys only inside their parent",
+			in:   `{"image":{"cuts":[1]},"epgAlternate":[{"title":"Recap","items":[{"image":{"cuts":[2]},"id":"1"}]}],"items":[{"image":{"cuts":[3]}}]}`,
+			keys: []string{"epgAlternate.image"},
+			want: `{"image":{"cuts":[1]},"epgAlternate":[{"title":"Recap","items":[{"image":null,"id":"1"}]}],"items":[{"image":{"cuts":[3]}}]}`,
+		},
+		{
+			name: "scope ends with its parent's value",
+			in:   `{"epgAlternate":{"image":1},"after":{"image":2}}`,
+			keys: []string{"epgAlternate.image"},
+			want: `{"epgAlternate":{"image":null},"after":{"image":2}}`,
+		},
+		{
+			name: "scalar parent opens no scope",
+			in:   `{"epgAlternate":"none","x":{"image":2}}`,
+			keys: []string{"epgAlternate.image"},
+			want: `{"epgAlternate":"none","x":{"image":2}}`,
+		},
+		{
+			name: "string values are not keys",
+			in:   `["k",{"a":"k","k":1}]`,
+			keys: []string{"k"},
+			want: `["k",{"a":"k","k":null}]`,
+		},
+		{
+			name: "default skip fields",
+			in:   `{"epgAlternate":[{"items":[{"image":{"cuts":[]},"keywordsAll":[{"value":"x"}]}]}],"highlights":{"items":[{"image":{"cuts":[{"src":"a","at2x":"b","at3x":"c"}]}}]}}`,
+			keys: DefaultSkipFields,
+			want: `{"epgAlternate":[{"items":[{"image":null,"keywordsAll":null}]}],"highlights":{"items":[{"image":{"cuts":[{"src":"a","at2x":null,"at3x":null}]}}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ioutil.ReadAll(newSkipReader(strings.NewReader(tt.in), tt.keys))
+			if err != nil {
+				t.Fatalf("ReadAll() = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// benchmarkSchedule returns a synthetic hydrated schedule of a full 15 game
+// day, shaped like StatsAPI's: every game has MLB.TV and audio feeds in its
+// epg and a few dozen videos in epgAlternate, each with a dozen image cuts,
+// their retina URLs, every rendition and dozens of keywords
+func benchmarkSchedule(b *testing.B) []byte {
+	b.Helper()
+	cuts := make([]map[string]interface{}, 12)
+	for i := range cuts {
+		w := 320 + 160*i
+		src := fmt.Sprintf("https://img.mlbstatic.com/mlb-images/image/upload/t_%dx%d/v1/mlb/abcdefghijklmnop", w, w*9/16)
+		cuts[i] = map[string]interface{}{"aspectRatio": "16:9", "width": w, "height": w * 9 / 16, "src": src, "at2x": src + "@2x", "at3x": src + "@3x"}
+	}
+	keywords := make([]map[string]string, 40)
+	for i := range keywords {
+		keywords[i] = map[string]string{"type": "taxonomy", "value": fmt.Sprintf("keyword-%d", i), "displayName": fmt.Sprintf("Keyword %d", i)}
+	}
+	playbacks := make([]map[string]string, 6)
+	for i := range playbacks {
+		playbacks[i] = map[string]string{"name": fmt.Sprintf("rendition-%d", i), "url": fmt.Sprintf("https://mlb-cuts-diamond.mlb.com/FORGE/2024/2024-04/01/clip_%d.mp4", i), "width": "1280", "height": "720"}
+	}
+	video := func(id int) map[string]interface{} {
+		return map[string]interface{}{
+			"id": strconv.Itoa(id), "date": "2024-04-01T23:10:00Z", "duration": "00:00:42",
+			"headline": "Judge's solo homer", "blurb": "Aaron Judge crushes a solo home run to left field",
+			"description": "Aaron Judge crushes a solo home run to left field in the 3rd inning",
+			"image":       map[string]interface{}{"title": "Judge homers", "cuts": cuts},
+			"keywordsAll": keywords, "keywordsDisplay": keywords[:5], "playbacks": playbacks,
+		}
+	}
+	feeds := make([]map[string]interface{}, 4)
+	for i := range feeds {
+		feeds[i] = map[string]interface{}{"callLetters": "NYY", "mediaFeedType": "HOME", "mediaState": "MEDIA_ARCHIVE", "id": i}
+	}
+
+	games := make([]map[string]interface{}, 15)
+	for g := range games {
+		highlights := make([]map[string]interface{}, 25)
+		for i := range highlights {
+			highlights[i] = video(g*100 + i)
+		}
+		games[g] = map[string]interface{}{
+			"gamePk":   745000 + g,
+			"gameDate": "2024-04-01T23:05:00Z",
+			"status":   map[string]string{"abstractGameCode": "F", "codedGameState": "F", "statusCode": "F", "detailedState": "Final"},
+			"content": map[string]interface{}{
+				"media": map[string]interface{}{
+					"epg": []map[string]interface{}{{"title": "MLBTV", "items": feeds}, {"title": "Audio", "items": feeds}},
+					"epgAlternate": []map[string]interface{}{
+						{"title": "Extended Highlights", "items": []map[string]interface{}{video(g*100 + 90)}},
+						{"title": "Daily Recap", "items": []map[string]interface{}{video(g*100 + 91)}},
+						{"title": "Highlights", "items": highlights},
+					},
+				},
+				"summary": map[string]bool{"hasHighlightsVideo": true},
+			},
+		}
+	}
+	body, err := json.Marshal(map[string]interface{}{"totalGames": len(games), "dates": []interface{}{map[string]interface{}{"date": "2024-04-01", "games": games}}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return body
+}
+
+// memoryTransport serves body for every request without a network round trip
+type memoryTransport []byte
+
+func (t memoryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Body:          ioutil.NopCloser(bytes.NewReader(t)),
+		ContentLength: int64(len(t)),
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Request:       req,
+		StatusCode:    http.StatusOK,
+	}, nil
+}
+
+// heapSampler tracks the high-water mark of the heap in use above a baseline
+// taken after a GC. B/op only counts bytes allocated, not how many are live at
+// once. Each sample stops the world, which inflates ns/op while it runs
+type heapSampler struct {
+	baseline uint64
+	done     chan struct{}
+	peak     chan uint64
+}
+
+// heapSampleInterval is how often a heapSampler reads the heap
+const heapSampleInterval = 200 * time.Microsecond
+
+// startHeapSampler collects garbage, then samples the heap in use until stop
+func startHeapSampler() *heapSampler {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	s := &heapSampler{baseline: m.HeapInuse, done: make(chan struct{}), peak: make(chan uint64)}
+	go func() {
+		var m runtime.MemStats
+		peak := s.baseline
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+		for {
+			runtime.ReadMemStats(&m)
+			if m.HeapInuse > peak {
+				peak = m.HeapInuse
+			}
+			select {
+			case <-s.done:
+				s.peak <- peak
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return s
+}
+
+// stop returns the peak heap in use above the baseline, in bytes
+func (s *heapSampler) stop() uint64 {
+	close(s.done)
+	return <-s.peak - s.baseline
+}
+
+// reportPeakHeap reports the peak heap in use sampled by s as the peak-heap-B metric
+func reportPeakHeap(b *testing.B, s *heapSampler) {
+	b.StopTimer()
+	b.ReportMetric(float64(s.stop()), "peak-heap-B")
+}
+
+// BenchmarkGetScheduleReadAll is GetSchedule as it was before streaming: the
+// whole body read into memory, then decoded with every subtree
+func BenchmarkGetScheduleReadAll(b *testing.B) {
+	body := benchmarkSchedule(b)
+	client := &http.Client{Transport: memoryTransport(body)}
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	heap := startHeapSampler()
+	defer reportPeakHeap(b, heap)
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get(DefaultBaseURL + "/api/v1/schedule")
+		if err != nil {
+			b.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			b.Fatal(err)
+		}
+		schedule := Schedule{}
+		err = json.Unmarshal(data, &schedule)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetScheduleStreaming is GetSchedule streaming the body past
+// DefaultSkipFields. json.Decoder still buffers the whole top-level value before
+// decoding it, so the savings come from the skipped fields never reaching the
+// decoder, not from decoding the schedule incrementally
+func BenchmarkGetScheduleStreaming(b *testing.B) {
+	body := benchmarkSchedule(b)
+	c := NewClient()
+	c.Cache = nil
+	c.HTTPClient = &http.Client{Transport: memoryTransport(body)}
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	heap := startHeapSampler()
+	defer reportPeakHeap(b, heap)
+	for i := 0; i < b.N; i++ {
+		_, err := c.GetSchedule(context.Background(), SportMLB, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
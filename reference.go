@@ -0,0 +1,182 @@
+package function
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+	"github.com/unrealities/warning-track-backend/transformers"
+)
+
+// referenceCollectionSuffix is appended to a game data collection to name its reference data collection
+const referenceCollectionSuffix = "-reference"
+
+// referenceMaxAge is how long the current season's stored teams and venues are
+// used before they are refreshed from StatsAPI. Past seasons never change
+const referenceMaxAge = 30 * 24 * time.Hour
+
+// rosterMaxAge is how long the current season's stored players are used
+// before active rosters are refreshed. Rosters change with every transaction
+const rosterMaxAge = 24 * time.Hour
+
+// maxRosterRequests bounds the roster and people requests made at once
+const maxRosterRequests = 4
+
+// maxPeoplePerRequest bounds the person IDs of each people request
+const maxPeoplePerRequest = 100
+
+// referenceData returns the season's teams, venues and players from Firestore,
+// refreshing them from StatsAPI when they are missing or stale
+func (s Service) referenceData(ctx context.Context) (transformers.Reference, error) {
+	season := s.Date.Year()
+	current := season >= time.Now().Year()
+	doc := s.FirestoreClient.Collection(s.DBCollection + referenceCollectionSuffix).Doc(strconv.Itoa(season))
+
+	ref := transformers.Reference{}
+	snap, err := doc.Get(ctx)
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return ref, err
+	default:
+		err = snap.DataTo(&ref)
+		if err != nil {
+			return ref, err
+		}
+	}
+	teamsStale := len(ref.Teams) == 0 || current && time.Since(ref.UpdatedAt) >= referenceMaxAge
+	playersStale := len(ref.Players) == 0 || current && time.Since(ref.PlayersUpdatedAt) >= rosterMaxAge
+	if !teamsStale && !playersStale {
+		return ref, nil
+	}
+
+	if teamsStale {
+		teams, err := s.StatsAPI.GetTeams(ctx, s.Sport, season)
+		if err != nil {
+			return ref, err
+		}
+		venues, err := s.StatsAPI.GetVenues(ctx, s.Sport, season)
+		if err != nil {
+			return ref, err
+		}
+		players, playersUpdatedAt := ref.Players, ref.PlayersUpdatedAt
+		ref = transformers.TransformReference(season, teams, venues)
+		ref.Players, ref.PlayersUpdatedAt = players, playersUpdatedAt
+		ref.UpdatedAt = time.Now()
+	}
+
+	// Players are only joined into probable pitchers, so stale rosters are kept on failure
+	if playersStale {
+		rosters, err := s.activeRosters(ctx, season, ref.Teams)
+		if err != nil {
+			s.WarningMsg("error refreshing active rosters", err)
+		} else {
+			ref.Players = transformers.TransformPlayers(rosters)
+			ref.PlayersUpdatedAt = time.Now()
+		}
+	}
+
+	_, err = doc.Set(ctx, ref)
+	if err != nil {
+		s.WarningMsg("error persisting reference data to Firebase", err)
+	}
+	s.DebugMsg("refreshed reference data")
+	return ref, nil
+}
+
+// activeRosters returns the people on every team's active roster, keyed by team ID
+func (s Service) activeRosters(ctx context.Context, season int, teams []transformers.TeamInfo) (map[int64][]mlbstats.Person, error) {
+	var (
+		firstErr error
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, maxRosterRequests)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	teamOf := map[int64]int64{}
+	for _, t := range teams {
+		teamID := t.ID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			roster, err := s.StatsAPI.GetRoster(ctx, teamID, season)
+			if err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, r := range roster {
+				teamOf[r.Person.ID] = teamID
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	ids := make([]int64, 0, len(teamOf))
+	for id := range teamOf {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rosters := map[int64][]mlbstats.Person{}
+	for start := 0; start < len(ids); start += maxPeoplePerRequest {
+		chunk := ids[start:min(start+maxPeoplePerRequest, len(ids))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			people, err := s.StatsAPI.GetPeople(ctx, chunk...)
+			if err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, p := range people {
+				teamID := teamOf[p.ID]
+				rosters[teamID] = append(rosters[teamID], p)
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return rosters, nil
+}
+
+// loadReferenceData returns the season's reference data. Reference data is
+// optional, so any failure only logs a warning and returns what was found.
+// Reading and refreshing it gets referenceTimeout, so a slow refresh leaves
+// the rest of the request time to enrich and persist the games
+func (s Service) loadReferenceData(ctx context.Context) transformers.Reference {
+	ctx, cancel := context.WithTimeout(ctx, referenceTimeout)
+	defer cancel()
+	ref, err := s.referenceData(ctx)
+	if err != nil {
+		s.WarningMsg("error getting reference data", err)
+	}
+	return ref
+}
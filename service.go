@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
@@ -12,69 +13,145 @@ import (
 	"cloud.google.com/go/logging"
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	firebase "firebase.google.com/go"
+	"github.com/unrealities/warning-track-backend/mlbstats"
 	"go.opencensus.io/trace"
 )
 
+// statsAPIClient is shared across invocations so warm instances reuse connections
+var statsAPIClient = newStatsAPIClient()
+
+// maxFeedTrackers bounds the live feeds kept in memory between invocations
+const maxFeedTrackers = 6
+
+// feedTrackers is shared across invocations so warm instances only download
+// the changes to each live game's feed
+var feedTrackers = mlbstats.NewFeedTrackers(statsAPIClient, maxFeedTrackers)
+
 // Service stores necessary information for the cloud function
 type Service struct {
 	Date            time.Time
 	DateFmt         string
 	DBCollection    string
+	Drift           *mlbstats.DriftReport
 	ErrorReporter   *errorreporting.Client
 	FirestoreClient *firestore.Client
 	FunctionName    string
 	Logger          *logging.Client
+	PostalCode      string
 	ProjectID       string
+	SchemaVersion   int
+	Sport           mlbstats.SportID
+	StatsAPI        *mlbstats.Client
+	StrictDecode    bool
 	TraceSpan       *trace.Span
 	Version         string
 }
 
 // LogMessage is a simple struct to ensure JSON formatting in logs
 type LogMessage struct {
-	Date         string `json:"date"`
-	DBCollection string `json:"dbCollection"`
-	Err          string `json:",omitempty"`
-	FunctionName string `json:"functionName"`
-	Msg          string `json:"msg"`
-	ProjectID    string `json:"projectID"`
-	Version      string `json:"version"`
+	Date            string `json:"date"`
+	DBCollection    string `json:"dbCollection"`
+	Err             string `json:",omitempty"`
+	FunctionName    string `json:"functionName"`
+	Msg             string `json:"msg"`
+	ProjectID       string `json:"projectID"`
+	Sport           string `json:"sport"`
+	StatsAPIBreaker string `json:"statsAPIBreaker"`
+	Version         string `json:"version"`
 }
 
 // DebugMsg logs a simple debug message with function name and version
 func (s Service) DebugMsg(msg string) {
-	s.Logger.Logger(s.FunctionName).Log(logging.Entry{
-		Severity: logging.Debug,
-		Payload: LogMessage{
-			Date:         s.Date.Format(s.DateFmt),
-			DBCollection: s.DBCollection,
-			FunctionName: s.FunctionName,
-			Msg:          msg,
-			ProjectID:    s.ProjectID,
-			Version:      s.Version,
-		},
-	})
+	s.Logger.Logger(s.FunctionName).Log(s.logEntry(logging.Debug, msg, nil))
 }
 
-// HandleFatalError produces an error report, cloud log message and standard log fatal
-func (s Service) HandleFatalError(msg string, err error) {
+// InfoMsg logs a notable event that needs no action
+func (s Service) InfoMsg(msg string) {
+	s.Logger.Logger(s.FunctionName).Log(s.logEntry(logging.Info, msg, nil))
+}
+
+// WarningMsg logs a warning that does not need an error report
+func (s Service) WarningMsg(msg string, err error) {
+	s.Logger.Logger(s.FunctionName).Log(s.logEntry(logging.Warning, msg, err))
+}
+
+// HandleError produces an error report and cloud log message, then responds
+// to the request with the given HTTP status code. The instance keeps running
+func (s Service) HandleError(w http.ResponseWriter, code int, msg string, err error) {
 	s.ErrorReporter.Report(errorreporting.Entry{Error: err})
-	s.Logger.Logger(s.FunctionName).Log(logging.Entry{
-		Severity: logging.Error,
-		Payload: LogMessage{
-			Date:         s.Date.Format(s.DateFmt),
-			DBCollection: s.DBCollection,
-			Err:          err.Error(),
-			FunctionName: s.FunctionName,
-			Msg:          msg,
-			ProjectID:    s.ProjectID,
-			Version:      s.Version,
-		},
-		Trace:  fmt.Sprintf("projects/%s/trace/%s", s.ProjectID, s.TraceSpan.SpanContext().TraceID.String()),
-		SpanID: s.TraceSpan.SpanContext().SpanID.String(),
-	})
+	s.Logger.Logger(s.FunctionName).Log(s.logEntry(logging.Error, msg, err))
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	http.Error(w, msg, code)
+}
+
+// HandleWarning produces a cloud log warning, without an error report, then
+// responds to the request with the given HTTP status code
+func (s Service) HandleWarning(w http.ResponseWriter, code int, msg string, err error) {
+	s.WarningMsg(msg, err)
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	http.Error(w, msg, code)
+}
+
+// ReportDrift logs StatsAPI schema drift found by strict decoding and reports
+// it to error reporting. An empty report is ignored
+func (s Service) ReportDrift(report *mlbstats.DriftReport) {
+	if report == nil || report.Empty() {
+		return
+	}
+	s.ErrorReporter.Report(errorreporting.Entry{Error: report})
+	s.Logger.Logger(s.FunctionName).Log(s.logEntry(logging.Warning, "StatsAPI schema drift detected", report))
+}
+
+// HandleFatalError produces an error report, cloud log message and standard log fatal.
+// Any schema drift collected so far is reported first, as deferred reports never run.
+// Clients that failed to initialize, e.g. when InitService fails, are skipped
+func (s Service) HandleFatalError(msg string, err error) {
+	if s.ErrorReporter != nil && s.Logger != nil {
+		s.ReportDrift(s.Drift)
+	}
+	if s.ErrorReporter != nil {
+		s.ErrorReporter.Report(errorreporting.Entry{Error: err})
+		s.ErrorReporter.Flush()
+	}
+	if s.Logger != nil {
+		logger := s.Logger.Logger(s.FunctionName)
+		logger.Log(s.logEntry(logging.Error, msg, err))
+		logger.Flush()
+	}
 	log.Fatalf("%s: %s", msg, err)
 }
 
+// logEntry builds a cloud log entry tied to the function's trace
+func (s Service) logEntry(severity logging.Severity, msg string, err error) logging.Entry {
+	payload := LogMessage{
+		Date:         s.Date.Format(s.DateFmt),
+		DBCollection: s.DBCollection,
+		FunctionName: s.FunctionName,
+		Msg:          msg,
+		ProjectID:    s.ProjectID,
+		Sport:        s.Sport.String(),
+		Version:      s.Version,
+	}
+	if err != nil {
+		payload.Err = err.Error()
+	}
+	if s.StatsAPI != nil {
+		payload.StatsAPIBreaker = s.StatsAPI.Breaker.State().String()
+	}
+
+	entry := logging.Entry{
+		Severity: severity,
+		Payload:  payload,
+	}
+	if s.TraceSpan != nil {
+		entry.Trace = fmt.Sprintf("projects/%s/trace/%s", s.ProjectID, s.TraceSpan.SpanContext().TraceID.String())
+		entry.SpanID = s.TraceSpan.SpanContext().SpanID.String()
+	}
+	return entry
+}
+
 // InitService initializes the function service with default
 func InitService(ctx context.Context) (Service, error) {
 	s := Service{
@@ -82,6 +159,9 @@ func InitService(ctx context.Context) (Service, error) {
 		DBCollection: os.Getenv("DB_COLLECTION"),
 		ProjectID:    os.Getenv("PROJECT_ID"),
 		FunctionName: os.Getenv("FN_NAME"),
+		Sport:        mlbstats.SportMLB,
+		StatsAPI:     statsAPIClient,
+		StrictDecode: os.Getenv("STRICT_DECODE") == "true",
 		Version:      os.Getenv("VERSION"),
 	}
 
@@ -124,3 +204,23 @@ func InitService(ctx context.Context) (Service, error) {
 
 	return s, nil
 }
+
+// newStatsAPIClient returns the StatsAPI client, pointed at STATS_API_URL when it is set.
+// STATS_API_RECORD_DIR records responses to fixtures and STATS_API_REPLAY_DIR
+// serves them back, so the function can run offline
+func newStatsAPIClient() *mlbstats.Client {
+	c := mlbstats.NewClient()
+	if u := os.Getenv("STATS_API_URL"); u != "" {
+		c.BaseURL = u
+	}
+	if dir := os.Getenv("STATS_API_RECORD_DIR"); dir != "" {
+		c.HTTPClient = &http.Client{Transport: mlbstats.NewRecorder(dir, mlbstats.ModeRecord)}
+	}
+	if dir := os.Getenv("STATS_API_REPLAY_DIR"); dir != "" {
+		c.HTTPClient = &http.Client{Transport: mlbstats.NewRecorder(dir, mlbstats.ModeReplay)}
+	}
+	if v := os.Getenv("VERSION"); v != "" {
+		c.UserAgent = mlbstats.DefaultUserAgent + "/" + v
+	}
+	return c
+}
@@ -0,0 +1,108 @@
+package transformers
+
+import (
+	"fmt"
+	"time"
+)
+
+// BaseballDayZone is the time zone baseball days are kept in, as the last
+// games of every day are played on the West Coast
+const BaseballDayZone = "America/Los_Angeles"
+
+// BaseballDayRollover is how long after midnight in BaseballDayZone the
+// previous baseball day carries on, so late and extra inning games stay on it
+const BaseballDayRollover = 4 * time.Hour
+
+// BaseballDay returns the baseball day at t, as midnight in BaseballDayZone.
+//
+// A game is filed under its StatsAPI officialDate, which is the baseball day
+// it was scheduled for whenever it ends. A suspended game is filed under the
+// day it was suspended with its ResumeDate, and again under the day it resumes
+// with its ResumedFromDate, where it is played to completion. Without a
+// requested date, the current baseball day is used, which only rolls over
+// BaseballDayRollover after midnight Pacific
+func BaseballDay(t time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(BaseballDayZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("transformers#BaseballDay: %w", err)
+	}
+	day := t.In(loc).Add(-BaseballDayRollover)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc), nil
+}
+
+// FileGames files each day's games, keyed by date (YYYY-MM-DD), under the
+// baseball days they belong to, keyed the same way. A game is filed under its
+// OfficialDate, a resumed game under the day it is played to completion, and
+// a suspended game also under its ResumeDate unless that day already lists it.
+// Every day of days is in the result, even when all of its games moved
+func FileGames(days map[string]AllSpark) map[string]AllSpark {
+	filed := make(map[string]AllSpark, len(days))
+	for day := range days {
+		filed[day] = AllSpark{Games: []Game{}, SchemaVersion: SchemaVersion}
+	}
+	file := func(day string, g Game, replace bool) {
+		spark, ok := filed[day]
+		if !ok {
+			spark = AllSpark{SchemaVersion: SchemaVersion}
+		}
+		for i, f := range spark.Games {
+			if f.MLBId == g.MLBId {
+				if replace {
+					spark.Games[i] = g
+				}
+				return
+			}
+		}
+		spark.Games = append(spark.Games, g)
+		filed[day] = spark
+	}
+
+	for day, spark := range days {
+		for _, g := range spark.Games {
+			home := g.OfficialDate
+			if home == "" || g.ResumedFromDate != "" {
+				home = day
+			}
+			file(home, g, true)
+		}
+	}
+	for _, spark := range days {
+		for _, g := range spark.Games {
+			if g.ResumeDate != "" && g.ResumedFromDate == "" {
+				file(g.ResumeDate, g, false)
+			}
+		}
+	}
+	return filed
+}
+
+// Refiled returns the games of a day's snapshot that were filed there from
+// another day's schedule, e.g. a suspended game under its ResumeDate
+func Refiled(games AllSpark, day string) AllSpark {
+	refiled := AllSpark{SchemaVersion: games.SchemaVersion}
+	for _, g := range games.Games {
+		if g.OfficialDate != "" && g.OfficialDate != day && g.ResumedFromDate == "" {
+			refiled.Games = append(refiled.Games, g)
+		}
+	}
+	return refiled
+}
+
+// MergeGames returns base with each of games replacing the game with the same
+// MLBId, or added when base does not have it
+func MergeGames(base, games AllSpark) AllSpark {
+	merged := AllSpark{Games: make([]Game, 0, len(base.Games)+len(games.Games)), SchemaVersion: SchemaVersion}
+	index := make(map[int64]int, len(base.Games))
+	for _, g := range base.Games {
+		index[g.MLBId] = len(merged.Games)
+		merged.Games = append(merged.Games, g)
+	}
+	for _, g := range games.Games {
+		if i, ok := index[g.MLBId]; ok {
+			merged.Games[i] = g
+			continue
+		}
+		merged.Games = append(merged.Games, g)
+	}
+	return merged
+}
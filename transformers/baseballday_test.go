@@ -0,0 +1,68 @@
+package transformers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileGames(t *testing.T) {
+	days := map[string]AllSpark{
+		"2024-06-01": {Games: []Game{
+			{MLBId: 1, OfficialDate: "2024-06-01"},
+			{MLBId: 2, OfficialDate: "2024-06-01", ResumeDate: "2024-06-02"},
+			{MLBId: 3, OfficialDate: "2024-05-31"},
+		}},
+		"2024-06-02": {Games: []Game{
+			{MLBId: 4, OfficialDate: "2024-06-02"},
+		}},
+	}
+
+	filed := FileGames(days)
+	want := map[string][]int64{
+		"2024-05-31": {3},
+		"2024-06-01": {1, 2},
+		"2024-06-02": {4, 2},
+	}
+	if len(filed) != len(want) {
+		t.Errorf("filed %d days, want %d", len(filed), len(want))
+	}
+	for day, ids := range want {
+		got := []int64{}
+		for _, g := range filed[day].Games {
+			got = append(got, g.MLBId)
+		}
+		if !reflect.DeepEqual(got, ids) {
+			t.Errorf("%s: games = %v, want %v", day, got, ids)
+		}
+	}
+
+	// Once resumed, the schedule lists the game under its resume date itself
+	days["2024-06-02"] = AllSpark{Games: []Game{
+		{MLBId: 2, OfficialDate: "2024-06-01", ResumedFromDate: "2024-06-01", Status: Status{State: "Live"}},
+		{MLBId: 4, OfficialDate: "2024-06-02"},
+	}}
+	filed = FileGames(days)
+	resumed := filed["2024-06-02"].Games
+	if len(resumed) != 2 || resumed[0].MLBId != 2 || resumed[0].Status.State != "Live" {
+		t.Errorf("2024-06-02: games = %+v, want the resumed game from its own schedule", resumed)
+	}
+}
+
+func TestRefiledAndMergeGames(t *testing.T) {
+	prev := AllSpark{Games: []Game{
+		{MLBId: 1, OfficialDate: "2024-06-02"},
+		{MLBId: 2, OfficialDate: "2024-06-01", ResumeDate: "2024-06-02"},
+	}}
+	refiled := Refiled(prev, "2024-06-02")
+	if len(refiled.Games) != 1 || refiled.Games[0].MLBId != 2 {
+		t.Fatalf("Refiled = %+v, want only the suspended game", refiled.Games)
+	}
+
+	merged := MergeGames(refiled, AllSpark{Games: []Game{{MLBId: 2, Status: Status{State: "Suspended"}}, {MLBId: 5}}})
+	if len(merged.Games) != 2 || merged.Games[0].Status.State != "Suspended" || merged.Games[1].MLBId != 5 {
+		t.Errorf("MergeGames = %+v", merged.Games)
+	}
+	if merged.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", merged.SchemaVersion, SchemaVersion)
+	}
+}
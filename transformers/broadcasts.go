@@ -0,0 +1,91 @@
+package transformers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// The Broadcast feeds
+const (
+	FeedAway     = "away"
+	FeedHome     = "home"
+	FeedNational = "national"
+)
+
+// The Broadcast media
+const (
+	MediumRadio = "radio"
+	MediumTV    = "tv"
+)
+
+// The Broadcast media states
+const (
+	MediaStateArchived = "archived"
+	MediaStateOff      = "off"
+	MediaStateOn       = "on"
+)
+
+// mediaStates maps StatsAPI media states to Broadcast media states
+var mediaStates = map[string]string{
+	"MEDIA_ARCHIVE": MediaStateArchived,
+	"MEDIA_OFF":     MediaStateOff,
+	"MEDIA_ON":      MediaStateOn,
+}
+
+// feedOrder sorts national broadcasts before the home and away feeds
+var feedOrder = map[string]int{FeedNational: 0, FeedHome: 1, FeedAway: 2}
+
+// broadcastsFromContent returns a game's TV and radio broadcasts from its
+// electronic program guide, national feeds first
+func broadcastsFromContent(c mlbstats.Content) []Broadcast {
+	var broadcasts []Broadcast
+	for _, group := range c.Media.Epg {
+		medium := MediumTV
+		if strings.Contains(strings.ToLower(group.Title), "audio") {
+			medium = MediumRadio
+		}
+		for _, item := range group.Items {
+			broadcasts = append(broadcasts, Broadcast{
+				AuthProvider: authProvider(item),
+				Feed:         strings.ToLower(item.MediaFeedType),
+				FreeGame:     item.FreeGame,
+				Language:     item.Language,
+				MediaState:   mediaStates[item.MediaState],
+				Medium:       medium,
+				Network:      item.CallLetters,
+			})
+		}
+	}
+	sort.SliceStable(broadcasts, func(i, j int) bool {
+		return feedRank(broadcasts[i].Feed) < feedRank(broadcasts[j].Feed)
+	})
+	return broadcasts
+}
+
+// feedRank orders a feed for display, unknown feeds last
+func feedRank(feed string) int {
+	if rank, ok := feedOrder[feed]; ok {
+		return rank
+	}
+	return len(feedOrder)
+}
+
+// authProvider returns the TV provider a viewer must sign in with to watch a
+// broadcast, or "" when MLB.TV alone is enough
+func authProvider(item mlbstats.EpgItem) string {
+	switch {
+	case item.EspnAuthRequired:
+		return "espn"
+	case item.FoxAuthRequired:
+		return "fox"
+	case item.Fs1AuthRequired:
+		return "fs1"
+	case item.MlbnAuthRequired:
+		return "mlbn"
+	case item.TbsAuthRequired:
+		return "tbs"
+	}
+	return ""
+}
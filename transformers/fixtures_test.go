@@ -0,0 +1,148 @@
+package transformers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// fixturesDir is the StatsAPI fixture corpus, see its README
+var fixturesDir = filepath.Join("..", "mlbstats", "testdata", "statsapi")
+
+// scenario is an entry of the corpus's scenarios.json
+type scenario struct {
+	Date   string `json:"date"`
+	GamePk int64  `json:"gamePk"`
+	Name   string `json:"name"`
+}
+
+// scenarioChecks checks the game of each scenario, found in both the StatsAPI
+// schedule and the AllSpark produced from it
+var scenarioChecks = map[string]func(t *testing.T, raw mlbstats.Game, g Game, day AllSpark){
+	"doubleheader": func(t *testing.T, raw mlbstats.Game, g Game, day AllSpark) {
+		games := 0
+		for _, other := range day.Games {
+			if other.Teams.AwayID == g.Teams.AwayID && other.Teams.HomeID == g.Teams.HomeID {
+				games++
+				if !other.Status.Over() {
+					t.Errorf("game %d: state = %s, want it over", other.MLBId, other.Status.State)
+				}
+			}
+		}
+		if games != 2 {
+			t.Errorf("found %d games between the doubleheader's teams, want 2", games)
+		}
+	},
+	"extra-innings": func(t *testing.T, raw mlbstats.Game, g Game, day AllSpark) {
+		if !g.Status.Over() || g.Status.Inning <= g.Status.ScheduledInnings {
+			t.Errorf("status = %+v, want a game over after %d innings", g.Status, g.Status.ScheduledInnings)
+		}
+		if g.Status.Score.Away == g.Status.Score.Home {
+			t.Errorf("score = %+v, want a winner", g.Status.Score)
+		}
+	},
+	"postponement": func(t *testing.T, raw mlbstats.Game, g Game, day AllSpark) {
+		if g.Status.State != mlbstats.PhasePostponed.String() || g.Status.InProgress {
+			t.Errorf("status = %+v, want postponed", g.Status)
+		}
+		if g.Status.Over() || g.Delay != nil {
+			t.Errorf("postponed game is over or delayed: %+v", g)
+		}
+	},
+	"spring-training": func(t *testing.T, raw mlbstats.Game, g Game, day AllSpark) {
+		if mlbstats.GameType(raw.GameType) != mlbstats.GameTypeSpringTraining {
+			t.Errorf("game type = %s, want spring training", raw.GameType)
+		}
+		if g.Teams.AwayTeam == nil || g.Teams.AwayTeam.Abbreviation == "" || g.Teams.HomeTeam == nil || g.Teams.HomeTeam.Abbreviation == "" {
+			t.Errorf("teams = %+v, want both teams' reference data", g.Teams)
+		}
+	},
+}
+
+// TestOptimusPrimeScenarios replays each scenario's schedule from the fixture
+// corpus and transforms it as GetGameDataByDay does
+func TestOptimusPrimeScenarios(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(fixturesDir, "scenarios.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scenarios []scenario
+	err = json.Unmarshal(data, &scenarios)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := mlbstats.NewClient()
+	client.Cache = nil
+	client.HTTPClient.Transport = mlbstats.NewRecorder(fixturesDir, mlbstats.ModeReplay)
+	ctx := context.Background()
+
+	for _, s := range scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", s.Date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedule, err := client.GetSchedule(ctx, mlbstats.SportMLB, date, mlbstats.GameConditionsHydrations...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			day, err := OptimusPrime(date, schedule)
+
+			if s.Name == "off-day" {
+				var empty *mlbstats.EmptyScheduleError
+				if !errors.As(err, &empty) {
+					t.Errorf("err = %v, want an EmptyScheduleError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, g := range day.Games {
+				if g.MLBId == 0 || g.GameTime.IsZero() || g.OfficialDate != s.Date {
+					t.Errorf("game %d: time %v, official date %q", g.MLBId, g.GameTime, g.OfficialDate)
+				}
+				if g.Venue == nil || g.Venue.TimeZone == "" || g.LocalGameTime == "" {
+					t.Errorf("game %d: venue %+v, local time %q, want the venue's time zone", g.MLBId, g.Venue, g.LocalGameTime)
+				}
+			}
+
+			days, err := client.GetScheduleRange(ctx, mlbstats.SportMLB, date, date, mlbstats.GameConditionsHydrations...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ranged := OptimusPrimeRange(days)[s.Date]; !reflect.DeepEqual(ranged, day) {
+				t.Errorf("the range schedule of %s transforms differently from the day's", s.Date)
+			}
+
+			check, ok := scenarioChecks[s.Name]
+			if !ok {
+				t.Fatalf("no checks for scenario %s", s.Name)
+			}
+			var raw mlbstats.Game
+			for _, d := range schedule.Dates {
+				for _, g := range d.Games {
+					if g.GamePk == s.GamePk {
+						raw = g
+					}
+				}
+			}
+			for _, g := range day.Games {
+				if g.MLBId == s.GamePk {
+					check(t, raw, g, day)
+					return
+				}
+			}
+			t.Errorf("game %d is not in the transformed schedule", s.GamePk)
+		})
+	}
+}
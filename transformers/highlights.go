@@ -0,0 +1,104 @@
+package transformers
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// maxHighlightClips is how many of a game's latest highlights are kept
+const maxHighlightClips = 5
+
+// preferredPlayback is the rendition of a clip the app plays
+const preferredPlayback = "mp4Avc"
+
+// thumbnailWidth is the width of the image cut preferred as a clip's thumbnail
+const thumbnailWidth = 640
+
+// HighlightsFromContent returns a game's latest highlight clips and whether a
+// condensed game or recap is available, or nil when the game has no video.
+// Schedules only carry the condensed game and recap, clips come from the game's content
+func HighlightsFromContent(c mlbstats.Content) *Highlights {
+	h := &Highlights{}
+	for _, group := range c.Media.EpgAlternate {
+		if len(group.Items) == 0 {
+			continue
+		}
+		switch group.Title {
+		case "Extended Highlights":
+			h.CondensedGame = true
+		case "Daily Recap":
+			h.Recap = true
+		}
+	}
+
+	items := c.Highlights.Highlights.Items
+	for _, item := range items {
+		headline := strings.ToLower(item.Headline + " " + item.Title)
+		if strings.Contains(headline, "condensed game") {
+			h.CondensedGame = true
+		}
+		if strings.Contains(headline, "recap") {
+			h.Recap = true
+		}
+	}
+
+	clips := make([]Clip, 0, len(items))
+	for _, item := range items {
+		clip, ok := clipFromMediaItem(item)
+		if ok {
+			clips = append(clips, clip)
+		}
+	}
+	sort.SliceStable(clips, func(i, j int) bool { return clips[i].Date.After(clips[j].Date) })
+	if len(clips) > maxHighlightClips {
+		clips = clips[:maxHighlightClips]
+	}
+	h.Clips = clips
+
+	if len(h.Clips) == 0 && !h.CondensedGame && !h.Recap {
+		return nil
+	}
+	return h
+}
+
+// clipFromMediaItem returns a Clip of a video, which must be playable
+func clipFromMediaItem(item mlbstats.MediaItem) (Clip, bool) {
+	clip := Clip{
+		Duration: item.Duration,
+		Headline: item.Headline,
+		ID:       item.ID,
+	}
+	clip.Date, _ = time.Parse(time.RFC3339, item.Date)
+
+	for _, p := range item.Playbacks {
+		if p.Name == preferredPlayback || clip.URL == "" {
+			clip.URL = p.URL
+		}
+	}
+	if clip.URL == "" {
+		return clip, false
+	}
+
+	var best int64
+	for _, cut := range item.Image.Cuts {
+		if cut.AspectRatio != "16:9" {
+			continue
+		}
+		if clip.Thumbnail == "" || abs64(cut.Width-thumbnailWidth) < abs64(best-thumbnailWidth) {
+			best = cut.Width
+			clip.Thumbnail = cut.Src
+		}
+	}
+	return clip, true
+}
+
+// abs64 returns the absolute value of n
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -2,6 +2,8 @@ package transformers
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/unrealities/warning-track-backend/mlbstats"
@@ -14,45 +16,330 @@ func OptimusPrime(date time.Time, schedule mlbstats.Schedule) (AllSpark, error)
 		return AllSpark{}, err
 	}
 
+	return transformDateData(d), nil
+}
+
+// OptimusPrimeRange takes the days of a mlbstats schedule range and produces an
+// AllSpark for each day, keyed the same way
+func OptimusPrimeRange(days map[string]mlbstats.DateData) map[string]AllSpark {
+	sparks := make(map[string]AllSpark, len(days))
+	for day, d := range days {
+		sparks[day] = transformDateData(d)
+	}
+	return sparks
+}
+
+// transformDateData produces an AllSpark from a single day of the mlbstats schedule
+func transformDateData(d mlbstats.DateData) AllSpark {
 	Games := make([]Game, len(d.Games))
 
 	for i, g := range d.Games {
 		Games[i].MLBId = g.GamePk
 		Games[i].MLBTVLink = fmt.Sprintf("https://www.mlb.com/tv/g%v", g.GamePk)
+		Games[i].SportID = g.Teams.Home.Team.Sport.ID
 
 		gameTime, err := time.Parse(time.RFC3339, g.GameDate)
 		if err != nil {
 			continue
 		}
 		Games[i].GameTime = gameTime
+		Games[i].OfficialDate = g.OfficialDate
+		if Games[i].OfficialDate == "" {
+			Games[i].OfficialDate = d.Date
+		}
+		Games[i].ResumeDate = g.ResumeDate
+		Games[i].ResumedFromDate = g.ResumedFromDate
 
 		Games[i].Teams = Teams{
-			AwayID: int(g.Teams.Away.Team.ID),
-			HomeID: int(g.Teams.Home.Team.ID),
-		}
-
-		Games[i].Status = Status{
-			BaseState: BaseState{
-				First:  g.Linescore.Offense.First.ID > 0,
-				Second: g.Linescore.Offense.Second.ID > 0,
-				Third:  g.Linescore.Offense.Third.ID > 0,
-			},
-			Count: Count{
-				Balls:   int(g.Linescore.Balls),
-				Strikes: int(g.Linescore.Strikes),
-			},
-			Inning:     int(g.Linescore.CurrentInning),
-			InProgress: g.Status.InProgress(),
-			Outs:       int(g.Linescore.Outs),
-			Score: Score{
-				Away: int(g.Linescore.Teams.Away.Runs),
-				Home: int(g.Linescore.Teams.Home.Runs),
-			},
-			TopOfInning: g.Linescore.IsTopInning,
+			AwayID:   int(g.Teams.Away.Team.ID),
+			AwayTeam: gameTeam(g.Teams.Away, g.SeriesGameNumber),
+			HomeID:   int(g.Teams.Home.Team.ID),
+			HomeTeam: gameTeam(g.Teams.Home, g.SeriesGameNumber),
+		}
+		Games[i].ProbablePitchers = ProbablePitchers{
+			Away: probablePitcher(g.Teams.Away.ProbablePitcher),
+			Home: probablePitcher(g.Teams.Home.ProbablePitcher),
+		}
+		Games[i].Broadcasts = broadcastsFromContent(g.Content)
+		Games[i].Highlights = HighlightsFromContent(g.Content)
+		if g.Weather.Condition != "" {
+			temp, _ := strconv.Atoi(g.Weather.Temp)
+			Games[i].Weather = &Weather{Condition: g.Weather.Condition, Temperature: temp, Wind: g.Weather.Wind}
+		}
+		if g.Status.IsDelayed() {
+			Games[i].Delay = &Delay{Minutes: int(g.GameInfo.DelayDurationMinutes), Reason: delayReason(g.Status.DetailedState)}
+		}
+		if g.Venue.ID > 0 {
+			Games[i].Venue = &VenueInfo{ID: g.Venue.ID, Name: g.Venue.Name, TimeZone: g.Venue.TimeZone.ID}
+			Games[i].LocalGameTime = localGameTime(gameTime, g.Venue.TimeZone.ID)
+		}
+
+		Games[i].Status = statusFromLinescore(g.Linescore, g.Status)
+		if Games[i].Status.ScheduledInnings == 0 {
+			Games[i].Status.ScheduledInnings = int(g.ScheduledInnings)
 		}
 
 		Games[i].LeverageIndex = Games[i].Status.LeverageIndex()
+
+		if pitcher := g.Linescore.Defense.Pitcher; pitcher.ID > 0 && Games[i].Status.InProgress {
+			Games[i].Pitchers.Current = &PitcherLine{Name: pitcher.FullName, PlayerID: pitcher.ID}
+		}
+	}
+
+	return AllSpark{Games: Games, SchemaVersion: SchemaVersion}
+}
+
+// StatusFromLiveFeed builds a game's Status from its pitch-by-pitch LiveFeed,
+// which is more current than the linescore hydrated on the schedule
+func StatusFromLiveFeed(feed mlbstats.LiveFeed) Status {
+	status := statusFromLinescore(feed.LiveData.Linescore, feed.GameData.Status)
+
+	// The current play's count is updated on every pitch. Once the play is
+	// complete the linescore has already moved on to the next batter
+	current := feed.LiveData.Plays.CurrentPlay
+	if !current.About.IsComplete && current.About.Inning > 0 {
+		status.Count = Count{
+			Balls:   int(current.Count.Balls),
+			Strikes: int(current.Count.Strikes),
+		}
+		status.Outs = int(current.Count.Outs)
+	}
+
+	return status
+}
+
+// UpdateFromLiveFeed replaces a game's Status, leverage index and current
+// pitcher with those of its live feed, which is more current than the schedule
+func UpdateFromLiveFeed(g *Game, feed mlbstats.LiveFeed) {
+	status := StatusFromLiveFeed(feed)
+	if status.ScheduledInnings == 0 {
+		status.ScheduledInnings = g.Status.ScheduledInnings
+	}
+	g.Status = status
+	g.LeverageIndex = status.LeverageIndex()
+	if pitcher := feed.LiveData.Linescore.Defense.Pitcher; pitcher.ID > 0 && status.InProgress {
+		g.Pitchers.Current = &PitcherLine{Name: pitcher.FullName, PlayerID: pitcher.ID}
+	}
+}
+
+// PitchersFromBoxscore returns both starters' lines and the line of the pitcher
+// with the given ID, usually the linescore's current pitcher, from a game's boxscore
+func PitchersFromBoxscore(box mlbstats.Boxscore, currentID int64) Pitchers {
+	pitchers := Pitchers{}
+	if starter, ok := box.Teams.Away.Starter(); ok {
+		pitchers.AwayStarter = pitcherLine(starter)
+	}
+	if starter, ok := box.Teams.Home.Starter(); ok {
+		pitchers.HomeStarter = pitcherLine(starter)
+	}
+	if currentID > 0 {
+		for _, team := range []mlbstats.BoxscoreTeam{box.Teams.Away, box.Teams.Home} {
+			if current, ok := team.Player(currentID); ok {
+				pitchers.Current = pitcherLine(current)
+			}
+		}
+	}
+	return pitchers
+}
+
+// pitcherLine builds a PitcherLine from a boxscore player's game stats
+func pitcherLine(p mlbstats.BoxscorePlayer) *PitcherLine {
+	stats := p.Stats.Pitching
+	pitchCount := stats.NumberOfPitches
+	if pitchCount == 0 {
+		pitchCount = stats.PitchesThrown
+	}
+	return &PitcherLine{
+		EarnedRuns:     int(stats.EarnedRuns),
+		Hits:           int(stats.Hits),
+		InningsPitched: stats.InningsPitched,
+		Name:           p.Person.FullName,
+		PitchCount:     int(pitchCount),
+		PlayerID:       p.Person.ID,
+		Runs:           int(stats.Runs),
+		Strikeouts:     int(stats.StrikeOuts),
+		Walks:          int(stats.BaseOnBalls),
+	}
+}
+
+// probablePitcher returns a team's probable pitcher with their season line, or nil when none is announced
+func probablePitcher(p mlbstats.ProbablePitcher) *ProbablePitcher {
+	if p.ID == 0 {
+		return nil
+	}
+	pp := &ProbablePitcher{Name: p.FullName, PlayerID: p.ID}
+	if stats, ok := p.SeasonPitching(); ok {
+		pp.ERA = stats.Era
+		pp.Losses = int(stats.Losses)
+		pp.StrikeoutsPer9 = stats.StrikeoutsPer9Inn
+		pp.WHIP = stats.Whip
+		pp.Wins = int(stats.Wins)
+	}
+	return pp
+}
+
+// delayReason returns the reason in a delayed status, e.g. "Rain" from "Delayed: Rain"
+func delayReason(detailedState string) string {
+	if i := strings.Index(detailedState, ":"); i >= 0 {
+		return strings.TrimSpace(detailedState[i+1:])
+	}
+	return ""
+}
+
+// DelayStarts dates each delayed game's delay from the previous snapshot of
+// the day, or from now when the delay is new, and counts its minutes
+func DelayStarts(prev, next AllSpark, now time.Time) AllSpark {
+	prevDelays := make(map[int64]*Delay, len(prev.Games))
+	for _, g := range prev.Games {
+		prevDelays[g.MLBId] = g.Delay
+	}
+
+	for i, g := range next.Games {
+		if g.Delay == nil {
+			continue
+		}
+		d := *g.Delay
+		d.Start = now
+		if p := prevDelays[g.MLBId]; p != nil && !p.Start.IsZero() {
+			d.Start = p.Start
+		}
+		if minutes := int(now.Sub(d.Start).Minutes()); minutes > d.Minutes {
+			d.Minutes = minutes
+		}
+		next.Games[i].Delay = &d
+	}
+	return next
+}
+
+// CarryOverFinal copies the pitchers, timeline, win probability and highlight
+// clips of every game that was already over in prev, as nothing about them
+// changes anymore and the schedule alone would leave them empty
+func CarryOverFinal(prev, next AllSpark) AllSpark {
+	prevGames := make(map[int64]Game, len(prev.Games))
+	for _, g := range prev.Games {
+		prevGames[g.MLBId] = g
+	}
+
+	for i, g := range next.Games {
+		p, ok := prevGames[g.MLBId]
+		if !ok || !p.Status.Over() || !g.Status.Over() {
+			continue
+		}
+		next.Games[i].Pitchers = Pitchers{AwayStarter: p.Pitchers.AwayStarter, HomeStarter: p.Pitchers.HomeStarter}
+		next.Games[i].Timeline = p.Timeline
+		next.Games[i].WinProbability = p.WinProbability
+		if p.Highlights != nil {
+			h := *p.Highlights
+			if g.Highlights != nil {
+				h.CondensedGame = h.CondensedGame || g.Highlights.CondensedGame
+				h.Recap = h.Recap || g.Highlights.Recap
+			}
+			next.Games[i].Highlights = &h
+		}
+	}
+	return next
+}
+
+// Enriched reports if a game has anything only its live feed, play by play
+// or win probability provide
+func (g Game) Enriched() bool {
+	return g.Pitchers.AwayStarter != nil || g.Pitchers.HomeStarter != nil || len(g.Timeline) > 0 || g.WinProbability != nil
+}
+
+// Over reports if the game has been completed
+func (s Status) Over() bool {
+	switch mlbstats.ParseGamePhase(s.State) {
+	case mlbstats.PhaseGameOver, mlbstats.PhaseFinal, mlbstats.PhaseForfeit:
+		return true
+	}
+	return false
+}
+
+// StarterChange is a probable pitcher replaced between two snapshots of a game
+type StarterChange struct {
+	From  ProbablePitcher
+	MLBId int64
+	Side  string
+	To    ProbablePitcher
+}
+
+func (c StarterChange) String() string {
+	return fmt.Sprintf("game %d: %s probable pitcher changed from %s (%d) to %s (%d)", c.MLBId, c.Side, c.From.Name, c.From.PlayerID, c.To.Name, c.To.PlayerID)
+}
+
+// StarterChanges compares two snapshots of a day's games and returns every
+// probable pitcher replaced before the game started
+func StarterChanges(prev, next AllSpark) []StarterChange {
+	prevGames := make(map[int64]Game, len(prev.Games))
+	for _, g := range prev.Games {
+		prevGames[g.MLBId] = g
+	}
+
+	var changes []StarterChange
+	for _, g := range next.Games {
+		p, ok := prevGames[g.MLBId]
+		if !ok || p.Status.InProgress {
+			continue
+		}
+		sides := []struct {
+			name     string
+			from, to *ProbablePitcher
+		}{
+			{"away", p.ProbablePitchers.Away, g.ProbablePitchers.Away},
+			{"home", p.ProbablePitchers.Home, g.ProbablePitchers.Home},
+		}
+		for _, side := range sides {
+			if side.from != nil && side.to != nil && side.from.PlayerID != side.to.PlayerID {
+				changes = append(changes, StarterChange{From: *side.from, MLBId: g.MLBId, Side: side.name, To: *side.to})
+			}
+		}
+	}
+	return changes
+}
+
+// statusFromLinescore builds a game's Status from a StatsAPI linescore
+func statusFromLinescore(ls mlbstats.Linescore, s mlbstats.Status) Status {
+	return Status{
+		BaseState: BaseState{
+			First:  ls.Offense.First.ID > 0,
+			Second: ls.Offense.Second.ID > 0,
+			Third:  ls.Offense.Third.ID > 0,
+		},
+		Count: Count{
+			Balls:   int(ls.Balls),
+			Strikes: int(ls.Strikes),
+		},
+		Inning:           int(ls.CurrentInning),
+		InProgress:       s.InProgress(),
+		Outs:             int(ls.Outs),
+		ScheduledInnings: int(ls.ScheduledInnings),
+		Score: Score{
+			Away: int(ls.Teams.Away.Runs),
+			Home: int(ls.Teams.Home.Runs),
+		},
+		State:       s.Phase().String(),
+		TopOfInning: ls.IsTopInning,
+	}
+}
+
+// StatusTransitionErrors compares two snapshots of a day's games and returns an
+// error for each game whose status moved between phases that cannot follow each other
+func StatusTransitionErrors(prev, next AllSpark) []error {
+	prevStates := make(map[int64]string, len(prev.Games))
+	for _, g := range prev.Games {
+		prevStates[g.MLBId] = g.Status.State
 	}
 
-	return AllSpark{Games}, nil
+	var errs []error
+	for _, g := range next.Games {
+		state, ok := prevStates[g.MLBId]
+		if !ok {
+			continue
+		}
+		err := mlbstats.ValidateTransition(mlbstats.ParseGamePhase(state), mlbstats.ParseGamePhase(g.Status.State))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("game %d: %w", g.MLBId, err))
+		}
+	}
+	return errs
 }
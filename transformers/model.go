@@ -5,7 +5,17 @@ import "time"
 // AllSpark contains all the necessary MLB data for Warning-Track to function
 // This is a reduced set of data from mlbStats.Schedule
 type AllSpark struct {
-	Games []Game `json:"games"`
+	Games         []Game `json:"games"`
+	SchemaVersion int    `json:"schemaVersion"`
+}
+
+// Availability is whether a game can be watched on MLB.TV from a postal code.
+// Blackout is why it cannot and Networks are the TV networks it airs on there
+type Availability struct {
+	Blackout   string   `json:"blackout,omitempty"`
+	MLBTV      bool     `json:"mlbTV"`
+	Networks   []string `json:"networks,omitempty"`
+	PostalCode string   `json:"postalCode"`
 }
 
 // BaseState is a simple vision of the base runner status
@@ -15,20 +25,152 @@ type BaseState struct {
 	Third  bool
 }
 
+// Broadcast is a TV or radio broadcast of a game. Feed is national, home or away
+type Broadcast struct {
+	AuthProvider string `json:"authProvider,omitempty"`
+	Feed         string `json:"feed"`
+	FreeGame     bool   `json:"freeGame"`
+	Language     string `json:"language"`
+	MediaState   string `json:"mediaState"`
+	Medium       string `json:"medium"`
+	Network      string `json:"network"`
+}
+
+// Clip is a highlight video of a game
+type Clip struct {
+	Date      time.Time `json:"date"`
+	Duration  string    `json:"duration"`
+	Headline  string    `json:"headline"`
+	ID        string    `json:"id"`
+	Thumbnail string    `json:"thumbnail"`
+	URL       string    `json:"url"`
+}
+
 // Count holds the game's current at-bat
 type Count struct {
 	Balls   int `json:"balls"`
 	Strikes int `json:"strikes"`
 }
 
-// Game holds all the necessary fields of a given game
+// Delay is an ongoing delay of a game. Start is when Warning-Track first saw
+// the delay, as StatsAPI does not report it
+type Delay struct {
+	Minutes int       `json:"minutes"`
+	Reason  string    `json:"reason"`
+	Start   time.Time `json:"start"`
+}
+
+// Game holds all the necessary fields of a given game. GameTime is in UTC and
+// LocalGameTime is the same time in the venue's time zone, as RFC 3339.
+// OfficialDate is the baseball day the game is filed under, see BaseballDay
 type Game struct {
-	GameTime      time.Time `json:"gameTime"`
-	LeverageIndex float32   `json:"leverageIndex"`
-	MLBId         int64     `json:"mlbID"`
-	MLBTVLink     string    `json:"mlbTVLink"`
-	Status        Status    `json:"status"`
-	Teams         Teams     `json:"teams"`
+	Availability        *Availability    `json:"availability,omitempty"`
+	Broadcasts          []Broadcast      `json:"broadcasts,omitempty"`
+	Delay               *Delay           `json:"delay,omitempty"`
+	GameTime            time.Time        `json:"gameTime"`
+	Highlights          *Highlights      `json:"highlights,omitempty"`
+	LeverageIndex       float32          `json:"leverageIndex"`
+	LocalGameTime       string           `json:"localGameTime,omitempty"`
+	MLBId               int64            `json:"mlbID"`
+	MLBTVLink           string           `json:"mlbTVLink"`
+	OfficialDate        string           `json:"officialDate"`
+	Pitchers            Pitchers         `json:"pitchers"`
+	PlayoffImplications []string         `json:"playoffImplications,omitempty"`
+	ProbablePitchers    ProbablePitchers `json:"probablePitchers"`
+	ResumeDate          string           `json:"resumeDate,omitempty"`
+	ResumedFromDate     string           `json:"resumedFromDate,omitempty"`
+	SportID             int64            `json:"sportID"`
+	Status              Status           `json:"status"`
+	Teams               Teams            `json:"teams"`
+	Timeline            []TimelineEvent  `json:"timeline,omitempty"`
+	Venue               *VenueInfo       `json:"venue,omitempty"`
+	Weather             *Weather         `json:"weather,omitempty"`
+	WinProbability      *WinProbability  `json:"winProbability,omitempty"`
+}
+
+// GameTeam is a team's side of a game: its reference data, its record going
+// into the game and where the game falls in the series
+type GameTeam struct {
+	TeamInfo
+	Record           *Record `json:"record,omitempty"`
+	SeriesGameNumber int     `json:"seriesGameNumber,omitempty"`
+	SplitSquad       bool    `json:"splitSquad,omitempty"`
+}
+
+// Highlights are a game's latest highlight clips and the longer videos available
+type Highlights struct {
+	Clips         []Clip `json:"clips"`
+	CondensedGame bool   `json:"condensedGame"`
+	Recap         bool   `json:"recap"`
+}
+
+// PitcherLine is a pitcher's line for the day
+type PitcherLine struct {
+	EarnedRuns     int    `json:"earnedRuns"`
+	Hits           int    `json:"hits"`
+	InningsPitched string `json:"inningsPitched"`
+	Name           string `json:"name"`
+	PitchCount     int    `json:"pitchCount"`
+	PlayerID       int64  `json:"playerID"`
+	Runs           int    `json:"runs"`
+	Strikeouts     int    `json:"strikeouts"`
+	Walks          int    `json:"walks"`
+}
+
+// Pitchers holds the pitchers worth knowing about before jumping into a game.
+// Lines are only known for games enriched with their boxscore
+type Pitchers struct {
+	AwayStarter *PitcherLine `json:"awayStarter,omitempty"`
+	Current     *PitcherLine `json:"current,omitempty"`
+	HomeStarter *PitcherLine `json:"homeStarter,omitempty"`
+}
+
+// ProbablePitcher is a team's announced starter and their season line
+type ProbablePitcher struct {
+	ERA            string `json:"era"`
+	Losses         int    `json:"losses"`
+	Name           string `json:"name"`
+	PitchHand      string `json:"pitchHand,omitempty"`
+	PlayerID       int64  `json:"playerID"`
+	StrikeoutsPer9 string `json:"strikeoutsPer9"`
+	WHIP           string `json:"whip"`
+	Wins           int    `json:"wins"`
+}
+
+// ProbablePitchers holds the pitching matchup of a game
+type ProbablePitchers struct {
+	Away *ProbablePitcher `json:"away,omitempty"`
+	Home *ProbablePitcher `json:"home,omitempty"`
+}
+
+// Record is a team's win-loss record
+type Record struct {
+	Losses int    `json:"losses"`
+	Pct    string `json:"pct"`
+	Wins   int    `json:"wins"`
+}
+
+// PlayerInfo is the reference data of a player on an active roster. Hands are
+// StatsAPI codes: L, R or S for switch hitters
+type PlayerInfo struct {
+	BatSide       string `json:"batSide"`
+	FullName      string `json:"fullName"`
+	ID            int64  `json:"id"`
+	PitchHand     string `json:"pitchHand"`
+	Position      string `json:"position"`
+	PrimaryNumber string `json:"primaryNumber"`
+	TeamID        int64  `json:"teamID"`
+}
+
+// Reference is a season's teams, venues and players on active rosters, stored
+// in Firestore and joined into game output
+type Reference struct {
+	Players          []PlayerInfo `json:"players"`
+	PlayersUpdatedAt time.Time    `json:"playersUpdatedAt"`
+	Season           int          `json:"season"`
+	Teams            []TeamInfo   `json:"teams"`
+	UpdatedAt        time.Time    `json:"updatedAt"`
+	Venues           []VenueInfo  `json:"venues"`
 }
 
 // Score holds the game's current score
@@ -37,20 +179,106 @@ type Score struct {
 	Home int `json:"home"`
 }
 
+// Standings is a day's standings snapshot, reduced from mlbstats.Standings
+type Standings struct {
+	Teams []TeamStanding `json:"teams"`
+}
+
 // Status hold's all the game's current fields. These fields all will change
 // during the course of a game
 type Status struct {
-	BaseState   BaseState `json:"baseState"`
-	Count       Count     `json:"count"`
-	Inning      int       `json:"inning"`
-	InProgress  bool      `json:"inProgress"`
-	Outs        int       `json:"outs"`
-	Score       Score     `json:"score"`
-	TopOfInning bool      `json:"topOfInning"`
+	BaseState        BaseState `json:"baseState"`
+	Count            Count     `json:"count"`
+	Inning           int       `json:"inning"`
+	InProgress       bool      `json:"inProgress"`
+	Outs             int       `json:"outs"`
+	ScheduledInnings int       `json:"scheduledInnings"`
+	Score            Score     `json:"score"`
+	State            string    `json:"state"`
+	TopOfInning      bool      `json:"topOfInning"`
+}
+
+// TeamInfo is the reference data of a team. StatsAPI has no team colors
+type TeamInfo struct {
+	Abbreviation string `json:"abbreviation"`
+	FileCode     string `json:"fileCode"`
+	ID           int64  `json:"id"`
+	LocationName string `json:"locationName"`
+	Name         string `json:"name"`
+	ShortName    string `json:"shortName"`
+	TeamName     string `json:"teamName"`
+}
+
+// TeamStanding is a team's place in its division and its league's wild card
+// race. Games back are negative for teams ahead of the last wild card
+type TeamStanding struct {
+	Clinched                  bool    `json:"clinched"`
+	DivisionGamesBack         float64 `json:"divisionGamesBack"`
+	DivisionID                int64   `json:"divisionID"`
+	DivisionRank              int     `json:"divisionRank"`
+	EliminationNumber         string  `json:"eliminationNumber"`
+	LeagueID                  int64   `json:"leagueID"`
+	Losses                    int     `json:"losses"`
+	MagicNumber               string  `json:"magicNumber"`
+	TeamID                    int64   `json:"teamID"`
+	WildCardEliminationNumber string  `json:"wildCardEliminationNumber"`
+	WildCardGamesBack         float64 `json:"wildCardGamesBack"`
+	WildCardRank              int     `json:"wildCardRank"`
+	Wins                      int     `json:"wins"`
 }
 
-// Teams holds the teams playing in a given game
+// Teams holds the teams playing in a given game. AwayID and HomeID are kept
+// for clients older than schema version 2, which only know the team IDs
 type Teams struct {
-	AwayID int `json:"away"`
-	HomeID int `json:"home"`
+	AwayID   int       `json:"away"`
+	AwayTeam *GameTeam `json:"awayTeam,omitempty"`
+	HomeID   int       `json:"home"`
+	HomeTeam *GameTeam `json:"homeTeam,omitempty"`
+}
+
+// TimelineEvent is a moment of a game worth catching up on. Score is the score after the event
+type TimelineEvent struct {
+	AtBatIndex    int      `json:"atBatIndex"`
+	Description   string   `json:"description"`
+	Inning        int      `json:"inning"`
+	LeverageIndex float32  `json:"leverageIndex"`
+	Score         Score    `json:"score"`
+	TopOfInning   bool     `json:"topOfInning"`
+	Types         []string `json:"types"`
+}
+
+// VenueInfo is the reference data of a venue
+type VenueInfo struct {
+	City      string  `json:"city"`
+	Country   string  `json:"country"`
+	ID        int64   `json:"id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name"`
+	RoofType  string  `json:"roofType"`
+	State     string  `json:"state"`
+	TimeZone  string  `json:"timeZone"`
+}
+
+// Weather is the conditions at a game's first pitch. Temperature is in Fahrenheit
+type Weather struct {
+	Condition   string `json:"condition"`
+	Temperature int    `json:"temperature"`
+	Wind        string `json:"wind"`
+}
+
+// WinProbability is StatsAPI's view of who will win a game, as percentages for the home team
+type WinProbability struct {
+	BiggestSwing WinProbabilitySwing `json:"biggestSwing"`
+	Home         float64             `json:"home"`
+	LastPlayWPA  float64             `json:"lastPlayWPA"`
+}
+
+// WinProbabilitySwing is the home win probability a single play added
+type WinProbabilitySwing struct {
+	AtBatIndex  int     `json:"atBatIndex"`
+	Description string  `json:"description"`
+	Inning      int     `json:"inning"`
+	TopOfInning bool    `json:"topOfInning"`
+	WPA         float64 `json:"wpa"`
 }
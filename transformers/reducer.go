@@ -4,6 +4,8 @@ import "github.com/unrealities/sabermetrics"
 
 // LeverageIndex uses a game's status and returns a leverage index (float64)
 // -1.0 is returned if there is an error
+// Games scheduled for fewer than 9 innings (e.g. 7-inning MiLB doubleheaders)
+// are aligned so their last scheduled inning is treated as the 9th
 func (s Status) LeverageIndex() float32 {
 	baseState := sabermetrics.BaseState{
 		First:  s.BaseState.First,
@@ -18,6 +20,9 @@ func (s Status) LeverageIndex() float32 {
 		Inning:      s.Inning,
 		TopOfInning: s.TopOfInning,
 	}
+	if s.ScheduledInnings > 0 && s.ScheduledInnings < 9 && s.Inning > 0 {
+		halfInning.Inning += 9 - s.ScheduledInnings
+	}
 
 	li, err := sabermetrics.LeverageIndex(baseState, score, halfInning, int(s.Outs))
 	if err != nil {
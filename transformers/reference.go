@@ -0,0 +1,173 @@
+package transformers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// TransformReference builds a season's Reference from StatsAPI teams and venues
+func TransformReference(season int, teams []mlbstats.Team, venues []mlbstats.Venue) Reference {
+	ref := Reference{
+		Season: season,
+		Teams:  make([]TeamInfo, 0, len(teams)),
+		Venues: make([]VenueInfo, 0, len(venues)),
+	}
+	for _, t := range teams {
+		if info := teamInfo(t); info != nil {
+			ref.Teams = append(ref.Teams, *info)
+		}
+	}
+	for _, v := range venues {
+		ref.Venues = append(ref.Venues, VenueInfo{
+			City:      v.Location.City,
+			Country:   v.Location.Country,
+			ID:        v.ID,
+			Latitude:  v.Location.DefaultCoordinates.Latitude,
+			Longitude: v.Location.DefaultCoordinates.Longitude,
+			Name:      v.Name,
+			RoofType:  v.FieldInfo.RoofType,
+			State:     v.Location.StateAbbrev,
+			TimeZone:  v.TimeZone.ID,
+		})
+	}
+	return ref
+}
+
+// TransformPlayers builds the Reference players from each team's active roster, keyed by team ID
+func TransformPlayers(rosters map[int64][]mlbstats.Person) []PlayerInfo {
+	var players []PlayerInfo
+	for teamID, people := range rosters {
+		for _, p := range people {
+			players = append(players, PlayerInfo{
+				BatSide:       p.BatSide.Code,
+				FullName:      p.FullName,
+				ID:            p.ID,
+				PitchHand:     p.PitchHand.Code,
+				Position:      p.PrimaryPosition.Abbreviation,
+				PrimaryNumber: p.PrimaryNumber,
+				TeamID:        teamID,
+			})
+		}
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+	return players
+}
+
+// JoinReference fills in every game's team and venue details and its probable
+// pitchers' hands from the Reference. The local start time is only recomputed
+// from the reference venue's time zone when the schedule had none
+func JoinReference(games AllSpark, ref Reference) AllSpark {
+	teams := make(map[int64]TeamInfo, len(ref.Teams))
+	for _, t := range ref.Teams {
+		teams[t.ID] = t
+	}
+	venues := make(map[int64]VenueInfo, len(ref.Venues))
+	for _, v := range ref.Venues {
+		venues[v.ID] = v
+	}
+	players := make(map[int64]PlayerInfo, len(ref.Players))
+	for _, p := range ref.Players {
+		players[p.ID] = p
+	}
+
+	for i, g := range games.Games {
+		if t, ok := teams[int64(g.Teams.AwayID)]; ok {
+			games.Games[i].Teams.AwayTeam = joinTeam(g.Teams.AwayTeam, t)
+		}
+		if t, ok := teams[int64(g.Teams.HomeID)]; ok {
+			games.Games[i].Teams.HomeTeam = joinTeam(g.Teams.HomeTeam, t)
+		}
+		games.Games[i].ProbablePitchers.Away = joinPitcher(g.ProbablePitchers.Away, players)
+		games.Games[i].ProbablePitchers.Home = joinPitcher(g.ProbablePitchers.Home, players)
+		if g.Venue == nil {
+			continue
+		}
+		if v, ok := venues[g.Venue.ID]; ok {
+			if v.TimeZone == "" {
+				v.TimeZone = g.Venue.TimeZone
+			}
+			games.Games[i].Venue = &v
+			if g.LocalGameTime == "" {
+				games.Games[i].LocalGameTime = localGameTime(g.GameTime, v.TimeZone)
+			}
+		}
+	}
+	return games
+}
+
+// joinPitcher returns a copy of a probable pitcher with the hand they throw with, when they are on an active roster
+func joinPitcher(p *ProbablePitcher, players map[int64]PlayerInfo) *ProbablePitcher {
+	if p == nil {
+		return nil
+	}
+	info, ok := players[p.PlayerID]
+	if !ok {
+		return p
+	}
+	joined := *p
+	joined.PitchHand = info.PitchHand
+	return &joined
+}
+
+// localGameTime returns a game time in the named time zone as RFC 3339, or ""
+// when the time or zone is unknown
+func localGameTime(t time.Time, timeZone string) string {
+	if t.IsZero() || timeZone == "" {
+		return ""
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// joinTeam returns a copy of a game's side with its reference data replaced by info
+func joinTeam(side *GameTeam, info TeamInfo) *GameTeam {
+	joined := GameTeam{}
+	if side != nil {
+		joined = *side
+	}
+	joined.TeamInfo = info
+	return &joined
+}
+
+// gameTeam returns a team's side of a scheduled game, or nil when the team is unknown
+func gameTeam(t mlbstats.TeamWithRecord, seriesGameNumber int64) *GameTeam {
+	if t.Team.ID == 0 {
+		return nil
+	}
+	side := &GameTeam{
+		Record: &Record{
+			Losses: int(t.LeagueRecord.Losses),
+			Pct:    t.LeagueRecord.Pct,
+			Wins:   int(t.LeagueRecord.Wins),
+		},
+		SeriesGameNumber: int(seriesGameNumber),
+		SplitSquad:       t.SplitSquad,
+	}
+	if info := teamInfo(t.Team); info != nil {
+		side.TeamInfo = *info
+	} else {
+		side.ID = t.Team.ID
+	}
+	return side
+}
+
+// teamInfo returns a team's reference data, or nil when the team was not hydrated
+func teamInfo(t mlbstats.Team) *TeamInfo {
+	if t.ID == 0 || t.Name == "" {
+		return nil
+	}
+	return &TeamInfo{
+		Abbreviation: t.Abbreviation,
+		FileCode:     t.FileCode,
+		ID:           t.ID,
+		LocationName: t.LocationName,
+		Name:         t.Name,
+		ShortName:    t.ShortName,
+		TeamName:     t.TeamName,
+	}
+}
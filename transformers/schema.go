@@ -0,0 +1,66 @@
+package transformers
+
+// SchemaVersion is the version of the AllSpark written by this package. Every
+// version keeps the team IDs in teams.away and teams.home.
+//
+//	0: teams are only known by their away and home IDs
+//	1: teams.awayTeam and teams.homeTeam add each team's names, abbreviation
+//	   and file code from the season's reference data
+//	2: each side also carries its record, series game number and split squad flag
+//
+// Versions 0 and 1 predate the schemaVersion field, so their snapshots read as 0
+const SchemaVersion = 2
+
+// LegacySchemaVersion is the version served to clients that do not ask for one,
+// as they were all written before versioning
+const LegacySchemaVersion = 1
+
+// Migrate upgrades an AllSpark written by an older version of this package,
+// e.g. a previous snapshot read back from Firestore, to SchemaVersion. Fields
+// that cannot be recovered from an old snapshot are left empty
+func Migrate(games AllSpark) AllSpark {
+	if games.SchemaVersion >= SchemaVersion {
+		return games
+	}
+	migrated := AllSpark{Games: make([]Game, len(games.Games)), SchemaVersion: SchemaVersion}
+	for i, g := range games.Games {
+		g.Teams.AwayID, g.Teams.AwayTeam = migrateTeam(g.Teams.AwayID, g.Teams.AwayTeam)
+		g.Teams.HomeID, g.Teams.HomeTeam = migrateTeam(g.Teams.HomeID, g.Teams.HomeTeam)
+		migrated.Games[i] = g
+	}
+	return migrated
+}
+
+// migrateTeam fills in whichever of a side's legacy ID and team is missing from the other
+func migrateTeam(id int, team *GameTeam) (int, *GameTeam) {
+	switch {
+	case team == nil && id > 0:
+		team = &GameTeam{TeamInfo: TeamInfo{ID: int64(id)}}
+	case team != nil && id == 0:
+		id = int(team.ID)
+	}
+	return id, team
+}
+
+// Downgrade returns games in the shape of an older schema version for clients
+// that asked for it. Newer fields are dropped and the legacy team IDs are kept
+func Downgrade(games AllSpark, version int) AllSpark {
+	if version >= games.SchemaVersion {
+		return games
+	}
+	downgraded := AllSpark{Games: make([]Game, len(games.Games)), SchemaVersion: version}
+	for i, g := range games.Games {
+		g.Teams.AwayTeam = downgradeTeam(g.Teams.AwayTeam, version)
+		g.Teams.HomeTeam = downgradeTeam(g.Teams.HomeTeam, version)
+		downgraded.Games[i] = g
+	}
+	return downgraded
+}
+
+// downgradeTeam returns a side of a game as it was in the given schema version
+func downgradeTeam(team *GameTeam, version int) *GameTeam {
+	if team == nil || version < 1 {
+		return nil
+	}
+	return &GameTeam{TeamInfo: team.TeamInfo}
+}
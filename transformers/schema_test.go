@@ -0,0 +1,59 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	games := Migrate(AllSpark{Games: []Game{
+		{Teams: Teams{AwayID: 147, HomeID: 111}},
+		{Teams: Teams{AwayTeam: &GameTeam{TeamInfo: TeamInfo{ID: 121}}, HomeTeam: &GameTeam{TeamInfo: TeamInfo{ID: 110}}}},
+	}})
+	if games.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", games.SchemaVersion, SchemaVersion)
+	}
+	for i, want := range [][2]int{{147, 111}, {121, 110}} {
+		teams := games.Games[i].Teams
+		if teams.AwayID != want[0] || teams.HomeID != want[1] {
+			t.Errorf("game %d: IDs = %d, %d, want %d, %d", i, teams.AwayID, teams.HomeID, want[0], want[1])
+		}
+		if teams.AwayTeam == nil || teams.AwayTeam.ID != int64(want[0]) || teams.HomeTeam == nil || teams.HomeTeam.ID != int64(want[1]) {
+			t.Errorf("game %d: teams = %+v, %+v", i, teams.AwayTeam, teams.HomeTeam)
+		}
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	games := AllSpark{SchemaVersion: SchemaVersion, Games: []Game{{Teams: Teams{
+		AwayID:   147,
+		AwayTeam: &GameTeam{TeamInfo: TeamInfo{Abbreviation: "NYY", ID: 147}, Record: &Record{Wins: 3}, SeriesGameNumber: 2},
+		HomeID:   111,
+		HomeTeam: &GameTeam{TeamInfo: TeamInfo{Abbreviation: "BOS", ID: 111}, SplitSquad: true},
+	}}}}
+
+	tests := []struct {
+		version int
+		want    string
+	}{
+		{0, `{"away":147,"home":111}`},
+		{1, `{"away":147,"awayTeam":{"abbreviation":"NYY","fileCode":"","id":147,"locationName":"","name":"","shortName":"","teamName":""},"home":111,"homeTeam":{"abbreviation":"BOS","fileCode":"","id":111,"locationName":"","name":"","shortName":"","teamName":""}}`},
+		{2, `{"away":147,"awayTeam":{"abbreviation":"NYY","fileCode":"","id":147,"locationName":"","name":"","shortName":"","teamName":"","record":{"losses":0,"pct":"","wins":3},"seriesGameNumber":2},"home":111,"homeTeam":{"abbreviation":"BOS","fileCode":"","id":111,"locationName":"","name":"","shortName":"","teamName":"","splitSquad":true}}`},
+	}
+	for _, tt := range tests {
+		got := Downgrade(games, tt.version)
+		if got.SchemaVersion != tt.version {
+			t.Errorf("version %d: SchemaVersion = %d", tt.version, got.SchemaVersion)
+		}
+		teams, err := json.Marshal(got.Games[0].Teams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(teams) != tt.want {
+			t.Errorf("version %d: teams = %s\nwant %s", tt.version, teams, tt.want)
+		}
+	}
+	if games.Games[0].Teams.AwayTeam.Record == nil {
+		t.Error("Downgrade modified its input")
+	}
+}
@@ -0,0 +1,170 @@
+package transformers
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// PlayoffRaceGames is how close, in games, teams must be to count as in a race
+const PlayoffRaceGames = 2.0
+
+// PlayoffRaceGamesRemaining is how few games teams must have left to count as
+// in a race. Early in the season every team is within a couple of games
+const PlayoffRaceGamesRemaining = 30
+
+// RegularSeasonGames is the length of the MLB regular season
+const RegularSeasonGames = 162
+
+// The playoff implications a Game can be tagged with
+const (
+	// TagClinchScenario means a win can clinch a playoff spot for either team
+	TagClinchScenario = "clinch-scenario"
+	// TagDivisionRace means both teams are in the same division and in its race, see PlayoffRaceGamesRemaining
+	TagDivisionRace = "division-race"
+	// TagEliminationScenario means a loss can eliminate either team
+	TagEliminationScenario = "elimination-scenario"
+	// TagWildCardRace means both teams are in their league's wild card race, see PlayoffRaceGamesRemaining
+	TagWildCardRace = "wild-card-race"
+)
+
+// TransformStandings reduces StatsAPI's regular season and wild card standings
+// to a single entry per team
+func TransformStandings(s mlbstats.Standings) Standings {
+	teams := map[int64]*TeamStanding{}
+	var order []int64
+	team := func(id int64) *TeamStanding {
+		t, ok := teams[id]
+		if !ok {
+			t = &TeamStanding{TeamID: id}
+			teams[id] = t
+			order = append(order, id)
+		}
+		return t
+	}
+
+	for _, r := range s.Records {
+		for _, tr := range r.TeamRecords {
+			t := team(tr.Team.ID)
+			switch mlbstats.StandingsType(r.StandingsType) {
+			case mlbstats.StandingsTypeRegularSeason:
+				t.Clinched = tr.Clinched
+				t.DivisionGamesBack = parseGamesBack(tr.DivisionGamesBack)
+				t.DivisionID = r.Division.ID
+				t.DivisionRank = parseRank(tr.DivisionRank)
+				t.EliminationNumber = tr.EliminationNumber
+				t.LeagueID = r.League.ID
+				t.Losses = int(tr.Losses)
+				t.MagicNumber = tr.MagicNumber
+				t.WildCardEliminationNumber = tr.WildCardEliminationNumber
+				t.Wins = int(tr.Wins)
+			case mlbstats.StandingsTypeWildCard:
+				t.WildCardGamesBack = parseGamesBack(tr.WildCardGamesBack)
+				t.WildCardRank = parseRank(tr.WildCardRank)
+			}
+		}
+	}
+
+	standings := Standings{Teams: make([]TeamStanding, len(order))}
+	for i, id := range order {
+		standings.Teams[i] = *teams[id]
+	}
+	return standings
+}
+
+// Team returns the standing of the team with the given ID
+func (s Standings) Team(id int64) (TeamStanding, bool) {
+	for _, t := range s.Teams {
+		if t.TeamID == id {
+			return t, true
+		}
+	}
+	return TeamStanding{}, false
+}
+
+// PlayoffImplications returns the playoff implication tags of a game between two teams in the standings
+func PlayoffImplications(g Game, s Standings) []string {
+	away, ok := s.Team(int64(g.Teams.AwayID))
+	if !ok || away.Wins+away.Losses == 0 {
+		return nil
+	}
+	home, ok := s.Team(int64(g.Teams.HomeID))
+	if !ok || home.Wins+home.Losses == 0 {
+		return nil
+	}
+
+	var tags []string
+	if away.clinchScenario() || home.clinchScenario() {
+		tags = append(tags, TagClinchScenario)
+	}
+	if away.DivisionID == home.DivisionID && away.inDivisionRace() && home.inDivisionRace() {
+		tags = append(tags, TagDivisionRace)
+	}
+	if away.eliminationScenario() || home.eliminationScenario() {
+		tags = append(tags, TagEliminationScenario)
+	}
+	if away.inWildCardRace() && home.inWildCardRace() {
+		tags = append(tags, TagWildCardRace)
+	}
+	return tags
+}
+
+// clinchScenario reports if one more win, or a rival's loss, clinches the team a playoff spot
+func (t TeamStanding) clinchScenario() bool {
+	return !t.Clinched && t.MagicNumber == "1"
+}
+
+// eliminationScenario reports if one more loss, or a rival's win, eliminates the team
+func (t TeamStanding) eliminationScenario() bool {
+	return t.EliminationNumber == "1" || t.WildCardEliminationNumber == "1"
+}
+
+// gamesRemaining returns the regular season games the team has left to play
+func (t TeamStanding) gamesRemaining() int {
+	return RegularSeasonGames - t.Wins - t.Losses
+}
+
+// lateSeason reports if the team has PlayoffRaceGamesRemaining or fewer games left
+func (t TeamStanding) lateSeason() bool {
+	return t.gamesRemaining() <= PlayoffRaceGamesRemaining
+}
+
+// inDivisionRace reports if the team is late in the season, not eliminated
+// from its division and within PlayoffRaceGames of its lead
+func (t TeamStanding) inDivisionRace() bool {
+	return t.lateSeason() && t.EliminationNumber != "E" && t.DivisionGamesBack <= PlayoffRaceGames
+}
+
+// inWildCardRace reports if the team is late in the season, not eliminated
+// from the wild card and within PlayoffRaceGames of the last wild card, ahead or behind
+func (t TeamStanding) inWildCardRace() bool {
+	return t.lateSeason() && t.WildCardEliminationNumber != "E" && t.WildCardRank > 0 && math.Abs(t.WildCardGamesBack) <= PlayoffRaceGames
+}
+
+// parseGamesBack parses StatsAPI games back, where "-" is the leader and "+1.5" is 1.5 games ahead
+func parseGamesBack(gb string) float64 {
+	gb = strings.TrimSpace(gb)
+	if gb == "" || gb == "-" {
+		return 0
+	}
+	ahead := strings.HasPrefix(gb, "+")
+	n, err := strconv.ParseFloat(strings.TrimPrefix(gb, "+"), 64)
+	if err != nil {
+		return 0
+	}
+	if ahead {
+		return -n
+	}
+	return n
+}
+
+// parseRank parses a StatsAPI rank, returning 0 when the team is not ranked
+func parseRank(rank string) int {
+	n, err := strconv.Atoi(rank)
+	if err != nil {
+		return 0
+	}
+	return n
+}
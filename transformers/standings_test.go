@@ -0,0 +1,48 @@
+package transformers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlayoffImplications(t *testing.T) {
+	game := Game{Teams: Teams{AwayID: 147, HomeID: 111}}
+	standings := func(played int, away, home TeamStanding) Standings {
+		for _, t := range []*TeamStanding{&away, &home} {
+			t.DivisionID = 201
+			t.Wins = played / 2
+			t.Losses = played - played/2
+		}
+		away.TeamID, home.TeamID = 147, 111
+		return Standings{Teams: []TeamStanding{away, home}}
+	}
+	leader := TeamStanding{DivisionRank: 1, WildCardGamesBack: -3, WildCardRank: 1}
+	chaser := TeamStanding{DivisionGamesBack: 1.5, DivisionRank: 2, WildCardGamesBack: 1, WildCardRank: 4}
+
+	tests := []struct {
+		name      string
+		standings Standings
+		want      []string
+	}{
+		{"april", standings(20, leader, chaser), nil},
+		{"september", standings(140, leader, chaser), []string{TagDivisionRace}},
+		{"september wild card", standings(140, TeamStanding{DivisionGamesBack: 8, WildCardRank: 3}, chaser), []string{TagWildCardRace}},
+		{"eliminated", standings(150, leader, TeamStanding{DivisionGamesBack: 2, EliminationNumber: "E", WildCardEliminationNumber: "E", WildCardGamesBack: 2, WildCardRank: 5}), nil},
+		{"elimination scenario", standings(160, leader, TeamStanding{DivisionGamesBack: 2, EliminationNumber: "1", WildCardGamesBack: 4, WildCardRank: 6}), []string{TagDivisionRace, TagEliminationScenario}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlayoffImplications(game, tt.standings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlayoffImplications = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGamesBack(t *testing.T) {
+	for gb, want := range map[string]float64{"-": 0, "": 0, "2.5": 2.5, "+1.5": -1.5, "E": 0} {
+		if got := parseGamesBack(gb); got != want {
+			t.Errorf("parseGamesBack(%q) = %v, want %v", gb, got, want)
+		}
+	}
+}
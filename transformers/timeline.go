@@ -0,0 +1,112 @@
+package transformers
+
+import (
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// The TimelineEvent types. A single play can be several at once, e.g. a go-ahead home run
+const (
+	TimelineHomeRun        = "home-run"
+	TimelineLeadChange     = "lead-change"
+	TimelinePitchingChange = "pitching-change"
+	TimelineScoringPlay    = "scoring-play"
+)
+
+// TimelineFromPlays builds a game's timeline of scoring plays, lead changes,
+// home runs and pitching changes. Each event's leverage index is that of the
+// moment before it happened
+func TimelineFromPlays(plays mlbstats.Plays, scheduledInnings int) []TimelineEvent {
+	var events []TimelineEvent
+	var prev *mlbstats.Play
+	lastLeader := 0
+
+	for i := range plays.AllPlays {
+		play := &plays.AllPlays[i]
+		if !play.About.IsComplete {
+			break
+		}
+		before := statusBeforePlay(prev, play, scheduledInnings)
+		li := before.LeverageIndex()
+
+		for _, e := range play.PlayEvents {
+			if e.Details.EventType != "pitching_substitution" {
+				continue
+			}
+			events = append(events, TimelineEvent{
+				AtBatIndex:    int(play.AtBatIndex),
+				Description:   e.Details.Description,
+				Inning:        before.Inning,
+				LeverageIndex: li,
+				Score:         before.Score,
+				TopOfInning:   before.TopOfInning,
+				Types:         []string{TimelinePitchingChange},
+			})
+		}
+
+		after := Score{Away: int(play.Result.AwayScore), Home: int(play.Result.HomeScore)}
+		var types []string
+		if play.About.IsScoringPlay {
+			types = append(types, TimelineScoringPlay)
+		}
+		if play.Result.EventType == "home_run" {
+			types = append(types, TimelineHomeRun)
+		}
+		if leader := after.leader(); leader != 0 {
+			if lastLeader != 0 && leader != lastLeader {
+				types = append(types, TimelineLeadChange)
+			}
+			lastLeader = leader
+		}
+		if len(types) > 0 {
+			events = append(events, TimelineEvent{
+				AtBatIndex:    int(play.AtBatIndex),
+				Description:   play.Result.Description,
+				Inning:        int(play.About.Inning),
+				LeverageIndex: li,
+				Score:         after,
+				TopOfInning:   play.About.IsTopInning,
+				Types:         types,
+			})
+		}
+		prev = play
+	}
+	return events
+}
+
+// statusBeforePlay returns the game's Status as play started, from the end of the previous play
+func statusBeforePlay(prev, play *mlbstats.Play, scheduledInnings int) Status {
+	status := Status{
+		Inning:           int(play.About.Inning),
+		InProgress:       true,
+		ScheduledInnings: scheduledInnings,
+		TopOfInning:      play.About.IsTopInning,
+	}
+	if prev == nil {
+		return status
+	}
+	status.Score = Score{Away: int(prev.Result.AwayScore), Home: int(prev.Result.HomeScore)}
+	if prev.About.Inning != play.About.Inning || prev.About.IsTopInning != play.About.IsTopInning {
+		return status
+	}
+	status.Outs = int(prev.Count.Outs)
+
+	// The previous play's matchup ends with every runner left on base, including
+	// those it did not involve, who are missing from its Runners
+	status.BaseState = BaseState{
+		First:  prev.Matchup.PostOnFirst.ID > 0,
+		Second: prev.Matchup.PostOnSecond.ID > 0,
+		Third:  prev.Matchup.PostOnThird.ID > 0,
+	}
+	return status
+}
+
+// leader returns -1 when the away team leads, 1 when the home team leads and 0 when tied
+func (s Score) leader() int {
+	switch {
+	case s.Away > s.Home:
+		return -1
+	case s.Home > s.Away:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package transformers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// testPlay returns a complete play of the given half inning ending with outs and the score
+func testPlay(atBat, inning int64, top bool, outs, away, home int64) mlbstats.Play {
+	p := mlbstats.Play{AtBatIndex: atBat}
+	p.About.AtBatIndex = atBat
+	p.About.Inning = inning
+	p.About.IsComplete = true
+	p.About.IsTopInning = top
+	p.Count.Outs = outs
+	p.Result.AwayScore = away
+	p.Result.HomeScore = home
+	return p
+}
+
+func TestStatusBeforePlay(t *testing.T) {
+	single := testPlay(0, 1, true, 0, 0, 0)
+	single.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+	single.Runners = make([]mlbstats.Runner, 1)
+	single.Runners[0].Details.Runner.ID = 1
+	single.Runners[0].Movement.End = "1B"
+
+	// Only the batter is in the strikeout's runners, the runner on first stays put
+	strikeout := testPlay(1, 1, true, 1, 0, 0)
+	strikeout.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+
+	double := testPlay(2, 1, true, 1, 1, 0)
+	double.Matchup.PostOnSecond = mlbstats.Player{ID: 3}
+
+	tests := []struct {
+		name       string
+		prev, play mlbstats.Play
+		first      bool
+		want       Status
+	}{
+		{
+			name:  "first play",
+			play:  single,
+			first: true,
+			want:  Status{Inning: 1, InProgress: true, ScheduledInnings: 9, TopOfInning: true},
+		},
+		{
+			name: "runner who did not move",
+			prev: strikeout,
+			play: double,
+			want: Status{BaseState: BaseState{First: true}, Inning: 1, InProgress: true, Outs: 1, ScheduledInnings: 9, TopOfInning: true},
+		},
+		{
+			name: "runner scored and batter on second",
+			prev: double,
+			play: testPlay(3, 1, true, 2, 1, 0),
+			want: Status{BaseState: BaseState{Second: true}, Inning: 1, InProgress: true, Outs: 1, ScheduledInnings: 9, Score: Score{Away: 1}, TopOfInning: true},
+		},
+		{
+			name: "new half inning",
+			prev: double,
+			play: testPlay(4, 1, false, 0, 1, 0),
+			want: Status{Inning: 1, InProgress: true, ScheduledInnings: 9, Score: Score{Away: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := &tt.prev
+			if tt.first {
+				prev = nil
+			}
+			if got := statusBeforePlay(prev, &tt.play, 9); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statusBeforePlay = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimelineFromPlays(t *testing.T) {
+	single := testPlay(1, 8, true, 0, 0, 1)
+	single.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+	strikeout := testPlay(2, 8, true, 1, 0, 1)
+	strikeout.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+	strikeout.PlayEvents = make([]mlbstats.PlayEvent, 1)
+	strikeout.PlayEvents[0].Details.EventType = "pitching_substitution"
+	strikeout.PlayEvents[0].Details.Description = "Pitching Change: Clay Holmes replaces Michael King."
+	homer := testPlay(3, 8, true, 1, 2, 1)
+	homer.About.IsScoringPlay = true
+	homer.Result.Description = "Rafael Devers homers (5) on a fly ball to right field."
+	homer.Result.EventType = "home_run"
+	inProgress := testPlay(4, 8, true, 1, 2, 1)
+	inProgress.About.IsComplete = false
+
+	plays := mlbstats.Plays{AllPlays: []mlbstats.Play{testPlay(0, 7, false, 3, 0, 1), single, strikeout, homer, inProgress}}
+	plays.AllPlays[0].About.IsScoringPlay = true
+	plays.AllPlays[0].Result.Description = "Aaron Judge scores."
+	events := TimelineFromPlays(plays, 9)
+
+	onFirst := Status{BaseState: BaseState{First: true}, Inning: 8, InProgress: true, Outs: 1, ScheduledInnings: 9, Score: Score{Home: 1}, TopOfInning: true}
+	want := []TimelineEvent{
+		{AtBatIndex: 0, Description: "Aaron Judge scores.", Inning: 7, LeverageIndex: Status{Inning: 7, InProgress: true, ScheduledInnings: 9}.LeverageIndex(), Score: Score{Home: 1}, Types: []string{TimelineScoringPlay}},
+		{AtBatIndex: 2, Description: "Pitching Change: Clay Holmes replaces Michael King.", Inning: 8, LeverageIndex: Status{BaseState: BaseState{First: true}, Inning: 8, InProgress: true, ScheduledInnings: 9, Score: Score{Home: 1}, TopOfInning: true}.LeverageIndex(), Score: Score{Home: 1}, TopOfInning: true, Types: []string{TimelinePitchingChange}},
+		{AtBatIndex: 3, Description: "Rafael Devers homers (5) on a fly ball to right field.", Inning: 8, LeverageIndex: onFirst.LeverageIndex(), Score: Score{Away: 2, Home: 1}, TopOfInning: true, Types: []string{TimelineScoringPlay, TimelineHomeRun, TimelineLeadChange}},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("TimelineFromPlays = %+v\nwant %+v", events, want)
+	}
+	if onFirst.LeverageIndex() == (Status{Inning: 8, InProgress: true, Outs: 1, ScheduledInnings: 9, Score: Score{Home: 1}, TopOfInning: true}).LeverageIndex() {
+		t.Error("a runner on first does not change the leverage index, the test cannot tell the bases apart")
+	}
+}
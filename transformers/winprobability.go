@@ -0,0 +1,79 @@
+package transformers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// LeverageIndexTolerance is how far our leverage index may be from StatsAPI's before it is flagged
+const LeverageIndexTolerance = 0.5
+
+// LeverageIndexError is returned when our leverage index disagrees with StatsAPI's for the same situation
+type LeverageIndexError struct {
+	AtBatIndex int
+	MLBId      int64
+	Ours       float32
+	StatsAPI   float64
+}
+
+func (e *LeverageIndexError) Error() string {
+	return fmt.Sprintf("game %d at bat %d: leverage index %.2f disagrees with StatsAPI's %.2f", e.MLBId, e.AtBatIndex, e.Ours, e.StatsAPI)
+}
+
+// WinProbabilityFromPlays summarizes a game's win probability from StatsAPI's per play win probability
+func WinProbabilityFromPlays(plays []mlbstats.WinProbabilityPlay) *WinProbability {
+	var wp *WinProbability
+	for _, p := range plays {
+		if !p.About.IsComplete {
+			continue
+		}
+		swing := WinProbabilitySwing{
+			AtBatIndex:  int(p.AtBatIndex),
+			Description: p.Result.Description,
+			Inning:      int(p.About.Inning),
+			TopOfInning: p.About.IsTopInning,
+			WPA:         p.HomeTeamWinProbabilityAdded,
+		}
+		if wp == nil {
+			wp = &WinProbability{BiggestSwing: swing}
+		}
+		if math.Abs(swing.WPA) > math.Abs(wp.BiggestSwing.WPA) {
+			wp.BiggestSwing = swing
+		}
+		wp.Home = p.HomeTeamWinProbability
+		wp.LastPlayWPA = p.HomeTeamWinProbabilityAdded
+	}
+	return wp
+}
+
+// CheckLeverageIndex compares our leverage index for the situation the last
+// completed play started in against StatsAPI's, returning a *LeverageIndexError
+// when they are more than LeverageIndexTolerance apart
+func CheckLeverageIndex(g Game, plays []mlbstats.WinProbabilityPlay) error {
+	last := -1
+	for i, p := range plays {
+		if p.About.IsComplete {
+			last = i
+		}
+	}
+	if last < 0 || plays[last].LeverageIndex <= 0 {
+		return nil
+	}
+
+	var prev *mlbstats.Play
+	if last > 0 {
+		prev = &plays[last-1].Play
+	}
+	ours := statusBeforePlay(prev, &plays[last].Play, g.Status.ScheduledInnings).LeverageIndex()
+	if ours < 0 || math.Abs(float64(ours)-plays[last].LeverageIndex) <= LeverageIndexTolerance {
+		return nil
+	}
+	return &LeverageIndexError{
+		AtBatIndex: int(plays[last].AtBatIndex),
+		MLBId:      g.MLBId,
+		Ours:       ours,
+		StatsAPI:   plays[last].LeverageIndex,
+	}
+}
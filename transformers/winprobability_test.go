@@ -0,0 +1,73 @@
+package transformers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/unrealities/warning-track-backend/mlbstats"
+)
+
+// testWinProbabilityPlays returns a top of the 9th where a runner reaches
+// first, stays there through a strikeout, and scores on a home run
+func testWinProbabilityPlays() []mlbstats.WinProbabilityPlay {
+	single := mlbstats.WinProbabilityPlay{Play: testPlay(0, 9, true, 0, 3, 4), HomeTeamWinProbability: 78.5, HomeTeamWinProbabilityAdded: -8.2}
+	single.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+	single.Result.Description = "Alex Verdugo singles on a line drive to left fielder."
+	strikeout := mlbstats.WinProbabilityPlay{Play: testPlay(1, 9, true, 1, 3, 4), HomeTeamWinProbability: 84.1, HomeTeamWinProbabilityAdded: 5.6}
+	strikeout.Matchup.PostOnFirst = mlbstats.Player{ID: 1}
+	strikeout.Result.Description = "Justin Turner strikes out swinging."
+	homer := mlbstats.WinProbabilityPlay{Play: testPlay(2, 9, true, 1, 5, 4), HomeTeamWinProbability: 21.3, HomeTeamWinProbabilityAdded: -62.8}
+	homer.Result.Description = "Rafael Devers homers (5) on a fly ball to right field."
+	pitching := mlbstats.WinProbabilityPlay{Play: testPlay(3, 9, true, 1, 5, 4), HomeTeamWinProbability: 21.3}
+	pitching.About.IsComplete = false
+	return []mlbstats.WinProbabilityPlay{single, strikeout, homer, pitching}
+}
+
+func TestWinProbabilityFromPlays(t *testing.T) {
+	if wp := WinProbabilityFromPlays(nil); wp != nil {
+		t.Errorf("WinProbabilityFromPlays(nil) = %+v, want nil", wp)
+	}
+
+	wp := WinProbabilityFromPlays(testWinProbabilityPlays())
+	if wp == nil {
+		t.Fatal("WinProbabilityFromPlays = nil")
+	}
+	if wp.Home != 21.3 || wp.LastPlayWPA != -62.8 {
+		t.Errorf("home %.1f, last play %.1f, want the last completed play's 21.3 and -62.8", wp.Home, wp.LastPlayWPA)
+	}
+	want := WinProbabilitySwing{AtBatIndex: 2, Description: "Rafael Devers homers (5) on a fly ball to right field.", Inning: 9, TopOfInning: true, WPA: -62.8}
+	if wp.BiggestSwing != want {
+		t.Errorf("BiggestSwing = %+v, want %+v", wp.BiggestSwing, want)
+	}
+}
+
+func TestCheckLeverageIndex(t *testing.T) {
+	g := Game{MLBId: 718355, Status: Status{ScheduledInnings: 9}}
+	// The home run started with a runner on first, who stayed put on the strikeout
+	ours := Status{BaseState: BaseState{First: true}, Inning: 9, InProgress: true, Outs: 1, ScheduledInnings: 9, Score: Score{Away: 3, Home: 4}, TopOfInning: true}.LeverageIndex()
+
+	tests := []struct {
+		name     string
+		statsAPI float64
+		wantErr  bool
+	}{
+		{"agrees", float64(ours), false},
+		{"within tolerance", float64(ours) + LeverageIndexTolerance/2, false},
+		{"disagrees", float64(ours) + 2*LeverageIndexTolerance, true},
+		{"not reported", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plays := testWinProbabilityPlays()
+			plays[2].LeverageIndex = tt.statsAPI
+			err := CheckLeverageIndex(g, plays)
+			var liErr *LeverageIndexError
+			if errors.As(err, &liErr) != tt.wantErr {
+				t.Fatalf("CheckLeverageIndex = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr && (liErr.AtBatIndex != 2 || liErr.MLBId != g.MLBId || liErr.Ours != ours) {
+				t.Errorf("error = %+v", liErr)
+			}
+		})
+	}
+}